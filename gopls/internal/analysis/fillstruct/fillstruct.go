@@ -269,11 +269,32 @@ func SuggestedFix(cpkg *cache.Package, pgf *parsego.File, start, end token.Pos)
 	index := bytes.Index(firstLine, trimmed)
 	whitespace := firstLine[:index]
 
+	sug, err := FormatLiteral(fset, file, expr, slices.Concat(expr.Elts, newElts), whitespace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return fset, &analysis.SuggestedFix{
+		TextEdits: []analysis.TextEdit{
+			{
+				Pos:     expr.Lbrace,
+				End:     expr.Rbrace + token.Pos(len("}")),
+				NewText: sug,
+			},
+		},
+	}, nil
+}
+
+// FormatLiteral returns the text of the composite literal expr of
+// file, from its opening brace to its closing one, with each of the
+// elements elts, and of the comments within expr, on a line of its
+// own, and with the given whitespace before each line but the first.
+func FormatLiteral(fset *token.FileSet, file *ast.File, expr *ast.CompositeLit, elts []ast.Expr, whitespace []byte) ([]byte, error) {
 	var buf bytes.Buffer
 	buf.WriteString("_{\n")
 	fcmap := ast.NewCommentMap(fset, file, file.Comments)
 	comments := fcmap.Filter(expr).Comments() // comments inside the expr, in source order
-	for _, elt := range slices.Concat(expr.Elts, newElts) {
+	for _, elt := range elts {
 		// Print comments before the current elt
 		for len(comments) > 0 && comments[0].Pos() < elt.Pos() {
 			for _, co := range comments[0].List {
@@ -285,7 +306,7 @@ func SuggestedFix(cpkg *cache.Package, pgf *parsego.File, start, end token.Pos)
 		// Print the current elt with comments
 		eltcomments := fcmap.Filter(elt).Comments()
 		if err := format.Node(&buf, fset, &printer.CommentedNode{Node: elt, Comments: eltcomments}); err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 		buf.WriteString(",")
 
@@ -311,23 +332,13 @@ func SuggestedFix(cpkg *cache.Package, pgf *parsego.File, start, end token.Pos)
 	buf.WriteString("}")
 	formatted, err := format.Source(buf.Bytes())
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	sug := indent(formatted, whitespace)
 	// Remove _
 	idx := bytes.IndexByte(sug, '{') // cannot fail
-	sug = sug[idx:]
-
-	return fset, &analysis.SuggestedFix{
-		TextEdits: []analysis.TextEdit{
-			{
-				Pos:     expr.Lbrace,
-				End:     expr.Rbrace + token.Pos(len("}")),
-				NewText: sug,
-			},
-		},
-	}, nil
+	return sug[idx:], nil
 }
 
 // populateMissingFields returns a slice of ast.Expr (specifically *ast.KeyValueExpr)
@@ -22,6 +22,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"golang.org/x/tools/go/types/objectpath"
 	"golang.org/x/tools/gopls/internal/cache/metadata"
@@ -65,6 +66,12 @@ type Snapshot struct {
 	// Sequence IDs for Snapshots from different Views cannot be compared.
 	sequenceID uint64
 
+	// detached reports whether the snapshot was derived by WithOverlays.
+	// A detached snapshot shares the sequence ID of the snapshot from
+	// which it was derived, and must not be used for anything keyed by
+	// it, such as diagnostics.
+	detached bool
+
 	// TODO(rfindley): the snapshot holding a reference to the view poses
 	// lifecycle problems: a view may be shut down and waiting for work
 	// associated with this snapshot to complete. While most accesses of the view
@@ -251,7 +258,12 @@ func (s *Snapshot) decref() {
 // Relative to their view sequence ids are monotonically increasing, but this
 // does not hold globally: when new views are created their initial snapshot
 // has sequence ID 0.
+//
+// Snapshots derived by WithOverlays have no sequence ID of their own.
 func (s *Snapshot) SequenceID() uint64 {
+	if s.detached {
+		bug.Reportf("SequenceID called on a detached snapshot")
+	}
 	return s.sequenceID
 }
 
@@ -260,7 +272,7 @@ func (s *Snapshot) SequenceID() uint64 {
 func (s *Snapshot) Labels() []label.Label {
 	return []label.Label{
 		label1.ViewID.Of(s.view.id),
-		label1.Snapshot.Of(s.SequenceID()),
+		label1.Snapshot.Of(s.sequenceID),
 		label1.Directory.Of(s.Folder().Path()),
 	}
 }
@@ -1484,6 +1496,49 @@ func inVendor(uri protocol.DocumentURI) bool {
 	return found && strings.Contains(after, "/")
 }
 
+// WithOverlays returns a copy of the snapshot in which the specified
+// files have the given contents, as if they had been edited but not
+// saved. The copy is detached from the view: it does not become the
+// view's current snapshot, and neither the session nor any other
+// snapshot observes the changes.
+//
+// The caller must call the release function when the copy is no
+// longer needed.
+func (s *Snapshot) WithOverlays(ctx context.Context, contents map[protocol.DocumentURI][]byte) (*Snapshot, func(), error) {
+	s.AwaitInitialized(ctx)
+	if ctx.Err() != nil {
+		return nil, nil, ctx.Err()
+	}
+
+	files := make(map[protocol.DocumentURI]file.Handle)
+	for uri, content := range contents {
+		fh, err := s.ReadFile(ctx, uri)
+		if err != nil {
+			return nil, nil, err
+		}
+		var version int32
+		if o, ok := fh.(*overlay); ok {
+			version = o.version
+		}
+		files[uri] = &overlay{
+			uri:     uri,
+			version: version,
+			content: content,
+			modTime: time.Now(),
+			kind:    s.FileKind(fh),
+			hash:    file.HashOf(content),
+		}
+	}
+	result, _ := s.clone(ctx, s.view.baseCtx, StateChange{Files: files}, func() {})
+	// The next sequence ID belongs to the next snapshot of the view.
+	result.sequenceID = s.sequenceID
+	result.detached = true
+	return result, func() {
+		result.cancel()
+		result.decref()
+	}, nil
+}
+
 // clone copies state from the receiver into a new Snapshot, applying the given
 // state changes.
 //
@@ -298,7 +298,7 @@ func (app *application) featureCommands() []command {
 		&execute{app: app},
 		&foldingRanges{app: app},
 		&format{app: app},
-		&headlessMCP{app: app},
+		&headlessMCP{app: app, Transport: "sse"},
 		&highlight{app: app},
 		&implementation{app: app},
 		&imports{app: app},
@@ -11,6 +11,8 @@ import (
 	"io"
 	"log"
 	"os"
+	"path/filepath"
+	"slices"
 	"sync"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -24,6 +26,7 @@ type headlessMCP struct {
 	app *application
 
 	Address      string `flag:"listen" help:"the address on which to run the mcp server"`
+	Transport    string `flag:"transport" help:"the HTTP transport of the -listen server: sse or streamable"`
 	Logfile      string `flag:"logfile" help:"filename to log to; if unset, logs to stderr"`
 	RPCTrace     bool   `flag:"rpc.trace" help:"print MCP rpc traces; cannot be used with -listen"`
 	Instructions bool   `flag:"instructions" help:"if set, print gopls' MCP instructions and exit"`
@@ -37,10 +40,17 @@ func (m *headlessMCP) ShortHelp() string { return "start the gopls MCP server in
 func (m *headlessMCP) DetailedHelp(f *flag.FlagSet) {
 	fmt.Fprint(f.Output(), `
 Starts the gopls MCP server in headless mode, without needing an LSP client.
-Starts the server over stdio or sse with http, depending on whether the listen flag is provided.
+Starts the server over stdio or http, depending on whether the listen flag is provided.
+
+Over http, the server uses the sse transport, or the streamable http transport
+if -transport=streamable. It accepts several concurrent MCP sessions, which
+share the state of a single gopls session. The workspace roots reported by
+each MCP client are added to the gopls workspace, and select the builds used
+by that client's workspace queries.
 
 Examples:
   $ gopls mcp -listen=localhost:3000
+  $ gopls mcp -listen=localhost:3000 -transport=streamable
   $ gopls mcp  //start over stdio
 `)
 	printFlagDefaults(f)
@@ -62,6 +72,12 @@ func (m *headlessMCP) Run(ctx context.Context, args ...string) error {
 		// disallow the -rpc.trace flag when using -listen.
 		return fmt.Errorf("-listen is incompatible with -rpc.trace")
 	}
+	transport := internalmcp.Transport(m.Transport)
+	switch transport {
+	case internalmcp.SSE, internalmcp.Streamable:
+	default:
+		return commandLineErrorf("invalid -transport %q: want %q or %q", m.Transport, internalmcp.SSE, internalmcp.Streamable)
+	}
 	if m.Logfile != "" {
 		f, err := os.Create(m.Logfile)
 		if err != nil {
@@ -144,8 +160,8 @@ func (m *headlessMCP) Run(ctx context.Context, args ...string) error {
 	// TODO(hxjiang): refactor the queue pattern into a helper function to avoid
 	// repetition.
 	var (
-		watchStop          = make(chan struct{}) // closed to broadcast "stop" event
-		watchQueueNonempty = make(chan struct{}) // each send indicates "nonempty"
+		watchStop          = make(chan struct{})    // closed to broadcast "stop" event
+		watchQueueNonempty = make(chan struct{}, 1) // each send indicates "nonempty" (buffered, so none is lost while draining)
 		watchQueueMu       sync.Mutex
 		watchQueue         []string
 	)
@@ -157,11 +173,17 @@ func (m *headlessMCP) Run(ctx context.Context, args ...string) error {
 	// watched roots are removed.
 	// TODO(hxjiang): implement [filewatcher.Watcher]'s method StopWatchDir.
 
-	// watchRoots is the callback triggered when the MCP client reports workspace
+	// watchRoots is the callback triggered when an MCP client reports workspace
 	// roots. We do not call w.WatchDir directly from this callback, but spawn a
 	// goroutine for it because WatchDir performs OS-level filesystem operations
 	// which can be slow. Blocking this callback would block the MCP server's
 	// JSON-RPC message loop and stall the entireconnection.
+	//
+	// The goroutine also adds each new root as a workspace folder, so that
+	// the MCP clients of a multi-module workspace each have a view of their
+	// own module. Folders are never removed, even when no connected client
+	// still reports them, as the roots handler doesn't know which client
+	// reported the roots, nor when a client disconnects.
 	watchRoots := func(res *mcp.ListRootsResult, err error) {
 		if err != nil {
 			errHandler(err)
@@ -197,18 +219,34 @@ func (m *headlessMCP) Run(ctx context.Context, args ...string) error {
 				watchQueue = nil
 				watchQueueMu.Unlock()
 
+				var added []protocol.WorkspaceFolder
 				for _, dir := range queue {
 					if err := w.WatchDir(dir); err != nil {
 						errHandler(err)
 					}
+					uri := protocol.URIFromPath(dir)
+					if !sess.HasView(uri) && !slices.ContainsFunc(added, func(f protocol.WorkspaceFolder) bool { return f.URI == string(uri) }) {
+						added = append(added, protocol.WorkspaceFolder{URI: string(uri), Name: filepath.Base(dir)})
+					}
+				}
+				if len(added) > 0 {
+					if err := cli.server.DidChangeWorkspaceFolders(ctx, &protocol.DidChangeWorkspaceFoldersParams{
+						Event: protocol.WorkspaceFoldersChangeEvent{Added: added},
+					}); err != nil {
+						log.Printf("failed to add workspace folders: %v", err)
+					}
 				}
 			}
 		}
 	}()
 
 	if m.Address != "" {
-		countHeadlessMCPSSE.Inc()
-		return internalmcp.Serve(ctx, m.Address, &staticSessions{sess, cli.server}, false, watchRoots)
+		if transport == internalmcp.Streamable {
+			countHeadlessMCPStreamable.Inc()
+		} else {
+			countHeadlessMCPSSE.Inc()
+		}
+		return internalmcp.Serve(ctx, m.Address, transport, &staticSessions{sess, cli.server}, false, watchRoots)
 	} else {
 		countHeadlessMCPStdIO.Inc()
 		var rpcLog io.Writer
@@ -18,11 +18,13 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	goplsmcp "golang.org/x/tools/gopls/internal/mcp"
+	"golang.org/x/tools/gopls/internal/protocol"
 	"golang.org/x/tools/gopls/internal/test/integration/fake"
 	"golang.org/x/tools/gopls/internal/vulncheck/vulntest"
 	"golang.org/x/tools/internal/testenv"
@@ -205,54 +207,7 @@ package b
 
 func MyFun() {}
 `)
-	port := strconv.Itoa(getRandomPort())
-	addr := "localhost:" + port
-	goplsCmd := exec.Command(os.Args[0], "-v", "mcp", "-listen="+addr)
-	goplsCmd.Env = append(os.Environ(), "ENTRYPOINT=goplsMain")
-	goplsCmd.Dir = tree
-	goplsCmd.Stdout = os.Stderr
-
-	// Pipe stderr to a scanner, so that we can wait for the log message that
-	// tells us the server has started.
-	stderr, err := goplsCmd.StderrPipe()
-	if err != nil {
-		t.Fatal(err)
-	}
-	// forward stdout to test output
-	if err := goplsCmd.Start(); err != nil {
-		t.Fatalf("starting gopls: %v", err)
-	}
-	defer func() {
-		if err := goplsCmd.Process.Kill(); err != nil {
-			t.Fatalf("killing gopls: %v", err)
-		}
-		// Wait for the gopls process to exit before we return and the test framework
-		// attempts to clean up the temporary directory.
-		// We expect an error because we killed the process.
-		goplsCmd.Wait()
-	}()
-
-	// Wait for the MCP server to start listening. The referenced log occurs
-	// after the connection is opened via net.Listen and the HTTP handlers are
-	// set up.
-	ready := make(chan bool)
-	go func() {
-		// Copy from the pipe to stderr, keeping an eye out for the "mcp http
-		// server listening" string.
-		scan := bufio.NewScanner(stderr)
-		for scan.Scan() {
-			line := scan.Text()
-			if strings.Contains(line, "mcp http server listening") {
-				ready <- true
-			}
-			fmt.Fprintln(os.Stderr, line)
-		}
-		if err := scan.Err(); err != nil {
-			t.Logf("reading from pipe: %v", err)
-		}
-	}()
-
-	<-ready
+	addr := startMCPServer(t, tree)
 	client := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "v0.0.1"}, nil)
 	ctx := t.Context()
 	mcpSession, err := client.Connect(ctx, &mcp.SSEClientTransport{Endpoint: "http://" + addr}, nil)
@@ -283,6 +238,71 @@ func MyFun() {}
 	}
 }
 
+func TestMCPCommandStreamable(t *testing.T) {
+	// Test that several clients may share a headless MCP server over the
+	// streamable transport, each with a view of its own module.
+	if !supportsFsnotify(runtime.GOOS) {
+		// See golang/go#74580
+		t.Skipf("skipping on %s; fsnotify is not supported", runtime.GOOS)
+	}
+	testenv.NeedsExec(t)
+	tree := writeTree(t, `
+-- a/go.mod --
+module example.com/a
+go 1.18
+-- a/a.go --
+package a
+
+func A() {}
+-- b/go.mod --
+module example.com/b
+go 1.18
+-- b/b.go --
+package b
+
+func B() {}
+`)
+	addr := startMCPServer(t, tree, "-transport=streamable")
+
+	ctx := t.Context()
+	var wg sync.WaitGroup
+	for _, mod := range []string{"a", "b"} {
+		wg.Go(func() {
+			client := mcp.NewClient(&mcp.Implementation{Name: "client-" + mod, Version: "v0.0.1"}, nil)
+			client.AddRoots(&mcp.Root{URI: string(protocol.URIFromPath(filepath.Join(tree, mod)))})
+			mcpSession, err := client.Connect(ctx, &mcp.StreamableClientTransport{Endpoint: "http://" + addr}, nil)
+			if err != nil {
+				t.Errorf("connecting to server: %v", err)
+				return
+			}
+			defer mcpSession.Close() // ignore error
+
+			// The roots are added to the workspace asynchronously.
+			var (
+				tool  = "go_workspace"
+				want  = "example.com/" + mod
+				other = map[string]string{"a": "example.com/b", "b": "example.com/a"}[mod]
+				got   string
+			)
+			for range 50 {
+				res, err := mcpSession.CallTool(ctx, &mcp.CallToolParams{Name: tool, Arguments: map[string]any{}})
+				if err != nil {
+					t.Errorf("CallTool(%s) failed: %v", tool, err)
+					return
+				}
+				if got = resultText(t, res); strings.Contains(got, want) {
+					break
+				}
+				time.Sleep(100 * time.Millisecond)
+			}
+			if !strings.Contains(got, want) || strings.Contains(got, other) {
+				t.Errorf("CallTool(%s) for client of module %s = %+v, want containing %q but not %q", tool, mod, got, want, other)
+			}
+		})
+	}
+	wg.Wait()
+}
+
 func TestMCPVulncheckCommand(t *testing.T) {
 	if !supportsFsnotify(runtime.GOOS) {
 		// See golang/go#74580
@@ -405,6 +425,61 @@ func main() {
 	}
 }
 
+// startMCPServer starts a headless gopls MCP server in the tree,
+// listening over HTTP with the specified additional flags, and returns
+// its address.
+func startMCPServer(t *testing.T, tree string, flags ...string) string {
+	port := strconv.Itoa(getRandomPort())
+	addr := "localhost:" + port
+	goplsCmd := exec.Command(os.Args[0], append([]string{"-v", "mcp", "-listen=" + addr}, flags...)...)
+	goplsCmd.Env = append(os.Environ(), "ENTRYPOINT=goplsMain")
+	goplsCmd.Dir = tree
+	goplsCmd.Stdout = os.Stderr
+
+	// Pipe stderr to a scanner, so that we can wait for the log message that
+	// tells us the server has started.
+	stderr, err := goplsCmd.StderrPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// forward stdout to test output
+	if err := goplsCmd.Start(); err != nil {
+		t.Fatalf("starting gopls: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := goplsCmd.Process.Kill(); err != nil {
+			t.Fatalf("killing gopls: %v", err)
+		}
+		// Wait for the gopls process to exit before we return and the test framework
+		// attempts to clean up the temporary directory.
+		// We expect an error because we killed the process.
+		goplsCmd.Wait()
+	})
+
+	// Wait for the MCP server to start listening. The referenced log occurs
+	// after the connection is opened via net.Listen and the HTTP handlers are
+	// set up.
+	ready := make(chan bool)
+	go func() {
+		// Copy from the pipe to stderr, keeping an eye out for the "mcp http
+		// server listening" string.
+		scan := bufio.NewScanner(stderr)
+		for scan.Scan() {
+			line := scan.Text()
+			if strings.Contains(line, "mcp http server listening") {
+				ready <- true
+			}
+			fmt.Fprintln(os.Stderr, line)
+		}
+		if err := scan.Err(); err != nil {
+			t.Logf("reading from pipe: %v", err)
+		}
+	}()
+
+	<-ready
+	return addr
+}
+
 // resultText concatenates the textual content of the given result, reporting
 // an error if any content values are non-textual.
 func resultText(t *testing.T, res *mcp.CallToolResult) string {
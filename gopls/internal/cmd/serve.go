@@ -109,7 +109,7 @@ func (s *serve) Run(ctx context.Context, args ...string) error {
 				}
 			}()
 
-			return mcp.Serve(ctx, s.MCPAddress, sessions, isDaemon, nil)
+			return mcp.Serve(ctx, s.MCPAddress, mcp.SSE, sessions, isDaemon, nil)
 		})
 	}
 
@@ -73,11 +73,13 @@ func (r *workspaceSymbol) Run(ctx context.Context, args ...string) error {
 		return err
 	}
 	for _, s := range symbols {
-		f, err := cli.openFile(ctx, s.Location.URI)
+		// The client doesn't resolve ranges lazily, so each location is complete.
+		loc, _ := s.Location.Value.(protocol.Location)
+		f, err := cli.openFile(ctx, loc.URI)
 		if err != nil {
 			return err
 		}
-		span, err := f.locationSpan(s.Location)
+		span, err := f.locationSpan(loc)
 		if err != nil {
 			return err
 		}
@@ -13,19 +13,24 @@ import (
 	"go/parser"
 	"go/token"
 	"go/types"
+	"path"
 	"regexp"
+	"slices"
+	"strconv"
 
 	goastutil "golang.org/x/tools/go/ast/astutil"
 	"golang.org/x/tools/gopls/internal/cache"
 	"golang.org/x/tools/gopls/internal/cache/parsego"
 	"golang.org/x/tools/gopls/internal/file"
 	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/protocol/command"
 	"golang.org/x/tools/gopls/internal/util/bug"
 	"golang.org/x/tools/gopls/internal/util/safetoken"
 	"golang.org/x/tools/gopls/internal/util/tokeninternal"
 	"golang.org/x/tools/imports"
 	"golang.org/x/tools/internal/astutil"
 	"golang.org/x/tools/internal/diff"
+	"golang.org/x/tools/internal/refactor"
 	"golang.org/x/tools/internal/refactor/inline"
 	"golang.org/x/tools/internal/typesinternal"
 )
@@ -119,10 +124,10 @@ func removeParam(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle,
 		return nil, fmt.Errorf("no param found")
 	}
 	// Write a transformation to remove the param.
-	var newParams []int
+	var newParams []command.ChangeSignatureParam
 	for i := 0; i < info.decl.Type.Params.NumFields(); i++ {
 		if i != info.paramIndex {
-			newParams = append(newParams, i)
+			newParams = append(newParams, command.ChangeSignatureParam{OldIndex: i})
 		}
 	}
 	return ChangeSignature(ctx, snapshot, pkg, pgf, rng, newParams)
@@ -133,28 +138,27 @@ func removeParam(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle,
 // surrounding rng.
 //
 // newParams expresses the new parameters for the signature in terms of the old
-// parameters. Each entry in newParams is the index of the new parameter in the
-// original parameter list. For example, given func Foo(a, b, c int) and newParams
-// [2, 0, 1], the resulting changed signature is Foo(c, a, b int). If newParams
-// omits an index of the original signature, that parameter is removed.
+// parameters. Each entry in newParams either references a parameter of the
+// original parameter list by its index, or describes a parameter to add. For
+// example, given func Foo(a, b, c int) and newParams [2, 0, 1], the resulting
+// changed signature is Foo(c, a, b int). If newParams omits an index of the
+// original signature, that parameter is removed.
+//
+// An added parameter has the form "name type", or "name type = expr", where
+// expr is the argument for the new parameter at existing calls. It is
+// evaluated in the scope of the function body, so it may refer to the other
+// parameters. If it is omitted, the argument is the zero value of the type.
 //
 // This operation is a work in progress. Remaining TODO:
-//   - Handle adding parameters.
 //   - Handle adding/removing/reordering results.
 //   - Improve the extra newlines in output.
 //   - Stream type checking via ForEachPackage.
 //   - Avoid unnecessary additional type checking.
-func ChangeSignature(ctx context.Context, snapshot *cache.Snapshot, pkg *cache.Package, pgf *parsego.File, rng protocol.Range, newParams []int) ([]protocol.DocumentChange, error) {
+func ChangeSignature(ctx context.Context, snapshot *cache.Snapshot, pkg *cache.Package, pgf *parsego.File, rng protocol.Range, newParams []command.ChangeSignatureParam) ([]protocol.DocumentChange, error) {
 	// Changes to our heuristics for whether we can remove a parameter must also
 	// be reflected in the canRemoveParameter helper.
-	if perrors, terrors := pkg.ParseErrors(), pkg.TypeErrors(); len(perrors) > 0 || len(terrors) > 0 {
-		var sample string
-		if len(perrors) > 0 {
-			sample = perrors[0].Error()
-		} else {
-			sample = terrors[0].Error()
-		}
-		return nil, fmt.Errorf("can't change signatures for packages with parse or type errors: (e.g. %s)", sample)
+	if err := checkPackageErrors(pkg, "change signatures"); err != nil {
+		return nil, err
 	}
 
 	info := findParam(pgf, rng)
@@ -166,19 +170,12 @@ func ChangeSignature(ctx context.Context, snapshot *cache.Snapshot, pkg *cache.P
 	// with the rewritten signature.
 
 	// Flatten, transform and regroup fields, using the flatField intermediate
-	// representation. A flatField is the result of flattening an *ast.FieldList
-	// along with type information.
-	type flatField struct {
-		name     string // empty if the field is unnamed
-		typeExpr ast.Expr
-		typ      types.Type
-	}
-
-	var newParamFields []flatField
+	// representation.
+	var oldParamFields []flatField
 	for id, field := range astutil.FlatFields(info.decl.Type.Params) {
 		typ := pkg.TypesInfo().TypeOf(field.Type)
 		if typ == nil {
-			return nil, fmt.Errorf("missing field type for field #%d", len(newParamFields))
+			return nil, fmt.Errorf("missing field type for field #%d", len(oldParamFields))
 		}
 		field := flatField{
 			typeExpr: field.Type,
@@ -187,35 +184,77 @@ func ChangeSignature(ctx context.Context, snapshot *cache.Snapshot, pkg *cache.P
 		if id != nil {
 			field.name = id.Name
 		}
-		newParamFields = append(newParamFields, field)
+		oldParamFields = append(oldParamFields, field)
 	}
 
-	// Select the new parameter fields.
-	newParamFields, ok := selectElements(newParamFields, newParams)
-	if !ok {
-		return nil, fmt.Errorf("failed to apply parameter transformation %v", newParams)
+	// Select the new parameter fields, and parse the added ones.
+	var (
+		newParamFields []flatField
+		added          = make(map[int]*addedParam) // keyed by index in newParams
+		named          = len(oldParamFields) == 0 || oldParamFields[0].name != ""
+	)
+	for i, p := range newParams {
+		if p.NewField == "" {
+			if p.OldIndex < 0 || p.OldIndex >= len(oldParamFields) {
+				return nil, fmt.Errorf("failed to apply parameter transformation: no parameter #%d", p.OldIndex)
+			}
+			newParamFields = append(newParamFields, oldParamFields[p.OldIndex])
+			continue
+		}
+		param, err := parseAddedParam(p.NewField)
+		if err != nil {
+			return nil, err
+		}
+		if !named {
+			param.name = "" // the other parameters are unnamed
+		} else if err := checkParamName(pkg.TypesInfo(), info.decl, param.name); err != nil {
+			return nil, err
+		}
+		added[i] = param
+		newParamFields = append(newParamFields, flatField{name: param.name, typeExpr: param.typeExpr})
+	}
+	for i, f := range newParamFields {
+		if is[*ast.Ellipsis](f.typeExpr) && i < len(newParamFields)-1 {
+			return nil, fmt.Errorf("the variadic parameter must be the last one")
+		}
 	}
 
-	// writeFields performs the regrouping of named fields.
-	writeFields := func(flatFields []flatField) *ast.FieldList {
-		list := new(ast.FieldList)
-		for i, f := range flatFields {
-			var field *ast.Field
-			if i > 0 && f.name != "" && flatFields[i-1].name != "" && types.Identical(f.typ, flatFields[i-1].typ) {
-				// Group named fields if they have the same type.
-				field = list.List[len(list.List)-1]
-			} else {
-				// Otherwise, create a new field.
-				field = &ast.Field{
-					Type: astutil.CloneNode(f.typeExpr),
-				}
-				list.List = append(list.List, field)
+	// Resolve the types of the added parameters, and their arguments at
+	// existing calls, importing packages as needed.
+	var imports []*types.Package
+	if len(added) > 0 {
+		var exprs []ast.Expr
+		for _, param := range added {
+			exprs = append(exprs, param.typeExpr)
+			if param.arg != nil {
+				exprs = append(exprs, param.arg)
 			}
-			if f.name != "" {
-				field.Names = append(field.Names, ast.NewIdent(f.name))
+		}
+		var err error
+		imports, err = newImports(ctx, snapshot, pkg, info.decl, exprs...)
+		if err != nil {
+			return nil, err
+		}
+		for i, param := range added {
+			typ, err := paramType(logger(ctx, "change signature", snapshot.Options().VerboseOutput), pkg, pgf, info.decl, param.typeExpr, imports)
+			if err != nil {
+				return nil, err
+			}
+			newParamFields[i].typ = typ
+			if param.arg == nil {
+				qual := typesinternal.FileQualifier(pgf.File, pkg.Types())
+				zero, ok := typesinternal.ZeroExpr(typ, func(p *types.Package) string {
+					if p.Path() == pkg.Types().Path() {
+						return ""
+					}
+					return qual(p)
+				})
+				if !ok {
+					return nil, fmt.Errorf("no zero value for parameter type %s; specify an argument for existing calls", types.ExprString(param.typeExpr))
+				}
+				param.arg = zero
 			}
 		}
-		return list
 	}
 
 	newDecl := astutil.CloneNode(info.decl)
@@ -251,8 +290,10 @@ func ChangeSignature(ctx context.Context, snapshot *cache.Snapshot, pkg *cache.P
 		// a map rather than a slice, as not every old param need exist in
 		// newParams.
 		oldParams := make(map[int]int)
-		for new, old := range newParams {
-			oldParams[old] = new
+		for new, p := range newParams {
+			if p.NewField == "" {
+				oldParams[p.OldIndex] = new
+			}
 		}
 		blanks := 0
 		paramIndex := 0 // global param index.
@@ -281,6 +322,9 @@ func ChangeSignature(ctx context.Context, snapshot *cache.Snapshot, pkg *cache.P
 			// (Only the last loop iteration matters.)
 			_, variadic = field.Type.(*ast.Ellipsis)
 		}
+		for i, param := range added {
+			args[i] = param.arg
+		}
 	}
 
 	// Step 3: Rewrite all referring calls, by swapping in the wrapper and
@@ -295,6 +339,7 @@ func ChangeSignature(ctx context.Context, snapshot *cache.Snapshot, pkg *cache.P
 		params:   params,
 		callArgs: args,
 		variadic: variadic,
+		imports:  imports,
 	})
 	if err != nil {
 		return nil, err
@@ -303,12 +348,13 @@ func ChangeSignature(ctx context.Context, snapshot *cache.Snapshot, pkg *cache.P
 	// Finally, rewrite the original declaration. We do this after inlining all
 	// calls, as there may be calls in the same file as the declaration. But none
 	// of the inlining should have changed the location of the original
-	// declaration.
+	// declaration, other than by adding imports.
 	{
 		idx := findDecl(pgf.File, info.decl)
 		if idx < 0 {
 			return nil, bug.Errorf("didn't find original decl")
 		}
+		idx -= importDecls(pgf.File)
 
 		src, ok := newContent[pgf.URI]
 		if !ok {
@@ -319,10 +365,49 @@ func ChangeSignature(ctx context.Context, snapshot *cache.Snapshot, pkg *cache.P
 		if err != nil {
 			return nil, err
 		}
+		if len(imports) > 0 {
+			src, err = addImports(src, imports)
+			if err != nil {
+				return nil, err
+			}
+		}
 		newContent[pgf.URI] = src
 	}
 
+	// If parameters are only added to a method, add them also to the
+	// methods that must continue to match it, such as those of the
+	// interfaces it implements, and to their calls. (Otherwise, only
+	// the static calls of the method are updated.)
+	if info.decl.Recv != nil && len(added) > 0 {
+		if ins, ok := paramInsertions(newParams, len(oldParamFields), added, newParamFields); ok {
+			if err := addCoupledParams(ctx, snapshot, pkg, pgf, info.decl, ins, imports, newContent); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Translate the resulting state into document changes.
+	return documentChanges(ctx, snapshot, newContent)
+}
+
+// checkPackageErrors returns an error if pkg has parse or type errors,
+// as the operation described by what would be unreliable.
+func checkPackageErrors(pkg *cache.Package, what string) error {
+	if perrors, terrors := pkg.ParseErrors(), pkg.TypeErrors(); len(perrors) > 0 || len(terrors) > 0 {
+		var sample string
+		if len(perrors) > 0 {
+			sample = perrors[0].Error()
+		} else {
+			sample = terrors[0].Error()
+		}
+		return fmt.Errorf("can't %s for packages with parse or type errors: (e.g. %s)", what, sample)
+	}
+	return nil
+}
+
+// documentChanges returns the document changes that replace the
+// content of each file of the snapshot by its new content.
+func documentChanges(ctx context.Context, snapshot *cache.Snapshot, newContent map[protocol.DocumentURI][]byte) ([]protocol.DocumentChange, error) {
 	var changes []protocol.DocumentChange
 	for uri, after := range newContent {
 		fh, err := snapshot.ReadFile(ctx, uri)
@@ -339,14 +424,266 @@ func ChangeSignature(ctx context.Context, snapshot *cache.Snapshot, pkg *cache.P
 		if err != nil {
 			return nil, fmt.Errorf("computing edits for %s: %v", uri, err)
 		}
-		change := protocol.DocumentChangeEdit(fh, textedits)
-		changes = append(changes, change)
+		changes = append(changes, protocol.DocumentChangeEdit(fh, textedits))
 	}
 	return changes, nil
 }
 
-// rewriteSignature rewrites the signature of the declIdx'th declaration in src
-// to use the signature of newDecl (described by fset).
+// A flatField is the result of flattening an *ast.FieldList along with type
+// information: the intermediate representation used to transform and regroup
+// fields.
+type flatField struct {
+	name     string // empty if the field is unnamed
+	typeExpr ast.Expr
+	typ      types.Type
+}
+
+// writeFields performs the regrouping of named fields.
+func writeFields(flatFields []flatField) *ast.FieldList {
+	list := new(ast.FieldList)
+	for i, f := range flatFields {
+		var field *ast.Field
+		if i > 0 && f.name != "" && flatFields[i-1].name != "" && types.Identical(f.typ, flatFields[i-1].typ) {
+			// Group named fields if they have the same type.
+			field = list.List[len(list.List)-1]
+		} else {
+			// Otherwise, create a new field.
+			field = &ast.Field{
+				Type: astutil.CloneNode(f.typeExpr),
+			}
+			list.List = append(list.List, field)
+		}
+		if f.name != "" {
+			field.Names = append(field.Names, ast.NewIdent(f.name))
+		}
+	}
+	return list
+}
+
+// An addedParam describes a parameter added by a signature change.
+type addedParam struct {
+	name     string
+	typeExpr ast.Expr
+	arg      ast.Expr // the argument at existing calls, or nil for the zero value
+}
+
+// parseAddedParam parses the description of an added parameter, of the form
+// "name type" or "name type = expr".
+func parseAddedParam(field string) (*addedParam, error) {
+	// Parse the field as a variable declaration, which has the same syntax.
+	file, err := parser.ParseFile(token.NewFileSet(), "", "package p; var "+field, parser.SkipObjectResolution)
+	if err == nil && len(file.Decls) == 1 {
+		spec := file.Decls[0].(*ast.GenDecl).Specs[0].(*ast.ValueSpec)
+		if len(spec.Names) == 1 && spec.Type != nil && len(spec.Values) <= 1 {
+			param := &addedParam{
+				name:     spec.Names[0].Name,
+				typeExpr: spec.Type,
+			}
+			if len(spec.Values) > 0 {
+				param.arg = spec.Values[0]
+			}
+			return param, nil
+		}
+	}
+	return nil, fmt.Errorf(`invalid parameter %q: want "name type" or "name type = expr"`, field)
+}
+
+// checkParamName reports an error if a parameter of the given name can't be
+// added to decl: because the name is already declared in the function
+// block, or because it would shadow an outer declaration used by the body.
+func checkParamName(info *types.Info, decl *ast.FuncDecl, name string) error {
+	if name == "_" {
+		return nil
+	}
+	scope := info.Scopes[decl.Type]
+	if scope == nil {
+		return bug.Errorf("missing function scope for %v", decl.Name.Name)
+	}
+	if obj := scope.Lookup(name); obj != nil {
+		return fmt.Errorf("can't add parameter %s: %s is already declared in %s", name, name, decl.Name.Name)
+	}
+	if decl.Body != nil {
+		for n := range ast.Preorder(decl.Body) {
+			if id, ok := n.(*ast.Ident); ok && id.Name == name {
+				// (Fields and methods have no parent scope.)
+				if obj := info.Uses[id]; obj != nil && obj.Parent() != nil && !scope.Contains(obj.Pos()) {
+					return fmt.Errorf("can't add parameter %s: it would shadow the declaration of %s used by the function body", name, name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// newImports returns the packages that the file declaring decl must newly
+// import, for the package qualifiers of the given expressions (which appear
+// in decl) to resolve. Each package is found by name among the dependencies
+// of pkg, preferring the nearest ones, or among the standard packages of
+// the workspace.
+func newImports(ctx context.Context, snapshot *cache.Snapshot, pkg *cache.Package, decl *ast.FuncDecl, exprs ...ast.Expr) ([]*types.Package, error) {
+	scope := pkg.TypesInfo().Scopes[decl.Type]
+	if scope == nil {
+		return nil, bug.Errorf("missing function scope for %v", decl.Name.Name)
+	}
+	var (
+		imports []*types.Package
+		done    = make(map[string]bool) // package names already resolved
+	)
+	for _, expr := range exprs {
+		for n := range ast.Preorder(expr) {
+			sel, ok := n.(*ast.SelectorExpr)
+			if !ok {
+				continue
+			}
+			id, ok := sel.X.(*ast.Ident)
+			if !ok || done[id.Name] {
+				continue
+			}
+			done[id.Name] = true
+			if _, obj := scope.LookupParent(id.Name, token.NoPos); obj != nil {
+				continue // already declared
+			}
+			imp, err := findImport(ctx, snapshot, pkg, id.Name)
+			if err != nil {
+				return nil, err
+			}
+			imports = append(imports, imp)
+		}
+	}
+	return imports, nil
+}
+
+// findImport returns the package of the given name that is the nearest
+// dependency of pkg (in breadth-first order, preferring a package whose
+// path is its name), or otherwise a standard package of that name
+// loaded by the workspace.
+func findImport(ctx context.Context, snapshot *cache.Snapshot, pkg *cache.Package, name string) (*types.Package, error) {
+	var (
+		found    *types.Package
+		seen     = make(map[*types.Package]bool)
+		toSearch = pkg.Types().Imports()
+	)
+	for len(toSearch) > 0 {
+		p := toSearch[0]
+		toSearch = toSearch[1:]
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		if p.Name() == name {
+			if p.Path() == name {
+				return p, nil
+			}
+			if found == nil {
+				found = p
+			}
+		}
+		toSearch = append(toSearch, p.Imports()...)
+	}
+	if found != nil {
+		return found, nil
+	}
+
+	// The package is not a dependency of pkg. Type-check it separately.
+	// (Its types are incommensurable with those of the dependencies of pkg,
+	// but this only matters if they are combined, which is unlikely.)
+	if mp := snapshot.Metadata(PackageID(name)); mp != nil && mp.PkgPath == PackagePath(name) {
+		pkgs, err := snapshot.TypeCheck(ctx, mp.ID)
+		if err != nil {
+			return nil, err
+		}
+		return pkgs[0].Types(), nil
+	}
+	return nil, fmt.Errorf("no package named %s among the dependencies of %s; import it first", name, pkg.Metadata().PkgPath)
+}
+
+// paramType returns the type denoted by the type expression of a parameter
+// added to decl, whose resolution may require the given new imports.
+func paramType(logf func(string, ...any), pkg *cache.Package, pgf *parsego.File, decl *ast.FuncDecl, typeExpr ast.Expr, imports []*types.Package) (types.Type, error) {
+	needsImport := false
+	for n := range ast.Preorder(typeExpr) {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok && slices.ContainsFunc(imports, func(p *types.Package) bool { return p.Name() == id.Name }) {
+				needsImport = true
+			}
+		}
+	}
+	if !needsImport {
+		// Check the expression in the scope of the signature.
+		info := &types.Info{Types: make(map[ast.Expr]types.TypeAndValue)}
+		if err := types.CheckExpr(pkg.FileSet(), pkg.Types(), decl.Type.Params.Closing, typeExpr, info); err != nil {
+			return nil, fmt.Errorf("invalid parameter type %s: %v", types.ExprString(typeExpr), err)
+		}
+		if tv := info.Types[typeExpr]; tv.IsType() {
+			return tv.Type, nil
+		}
+		return nil, fmt.Errorf("invalid parameter type: %s is not a type", types.ExprString(typeExpr))
+	}
+
+	// Check the expression in a package-level declaration of a modified
+	// copy of the file, which imports the new packages.
+	src := fmt.Appendf(bytes.Clone(pgf.Src), "\n\nvar _ %s\n", types.ExprString(typeExpr))
+	src, err := addImports(src, imports)
+	if err != nil {
+		return nil, err
+	}
+	file, err := parser.ParseFile(pkg.FileSet(), pgf.URI.Path(), src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	_, info, err := reTypeCheck(logf, pkg, map[protocol.DocumentURI]*ast.File{pgf.URI: file}, imports, false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameter type %s: %v", types.ExprString(typeExpr), err)
+	}
+	spec := file.Decls[len(file.Decls)-1].(*ast.GenDecl).Specs[0].(*ast.ValueSpec)
+	return info.TypeOf(spec.Type), nil
+}
+
+// addImports adds imports of the given packages to the Go source file src,
+// unless they are already imported.
+func addImports(src []byte, pkgs []*types.Package) ([]byte, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", src, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, bug.Errorf("parsing file to add imports: %v", err)
+	}
+	var edits []diff.Edit
+	for _, imp := range pkgs {
+		if slices.ContainsFunc(file.Imports, func(spec *ast.ImportSpec) bool { return spec.Path.Value == strconv.Quote(imp.Path()) }) {
+			continue
+		}
+		name := ""
+		if imp.Name() != path.Base(imp.Path()) {
+			name = imp.Name()
+		}
+		edits = append(edits, diffEdits(file, refactor.AddImportEdits(file, name, imp.Path()))...)
+	}
+	if len(edits) == 0 {
+		return src, nil
+	}
+	src, err = diff.ApplyBytes(src, edits)
+	if err != nil {
+		return nil, bug.Errorf("adding imports: %v", err)
+	}
+	return imports.Process("output", src, &imports.Options{Comments: true, TabIndent: true, TabWidth: 8, FormatOnly: true})
+}
+
+// diffEdits converts edits to file, whose positions are tokens, to
+// edits whose positions are byte offsets in the file.
+func diffEdits(file *ast.File, edits []refactor.Edit) []diff.Edit {
+	dedits := make([]diff.Edit, len(edits))
+	for i, edit := range edits {
+		dedits[i] = diff.Edit{
+			Start: int(edit.Pos - file.FileStart),
+			End:   int(edit.End - file.FileStart),
+			New:   string(edit.NewText),
+		}
+	}
+	return dedits
+}
+
+// rewriteSignature rewrites the signature of the declIdx'th declaration in src,
+// not counting import declarations, to use the signature of newDecl (described
+// by fset).
 //
 // TODO(rfindley): I think this operation could be generalized, for example by
 // using a concept of a 'nodepath' to correlate nodes between two related
@@ -361,6 +698,10 @@ func rewriteSignature(fset *token.FileSet, declIdx int, src0 []byte, newDecl *as
 	if err != nil {
 		return nil, bug.Errorf("re-parsing declaring file failed: %v", err)
 	}
+	declIdx += importDecls(file0)
+	if declIdx >= len(file0.Decls) {
+		return nil, bug.Errorf("inlining affected declaration order: no declaration #%d", declIdx)
+	}
 	decl0, _ := file0.Decls[declIdx].(*ast.FuncDecl)
 	// Inlining shouldn't have changed the location of any declarations, but do
 	// a sanity check.
@@ -484,8 +825,15 @@ type signatureRewrite struct {
 	params            *ast.FieldList
 	callArgs          []ast.Expr
 	variadic          bool
+	imports           []*types.Package // packages newly imported by the declaring file
 }
 
+// delegateTag is a unique prefix that is added to the name of the
+// synthetic delegate declared by rewriteCalls.
+//
+// It must have a ~0% probability of causing collisions with existing names.
+const delegateTag = "G_o_p_l_s_"
+
 // rewriteCalls returns the document changes required to rewrite the
 // signature of origDecl to that of newDecl.
 //
@@ -527,11 +875,6 @@ type signatureRewrite struct {
 // signature refactorings that may affect the function body, such as removing
 // or adding return values.
 func rewriteCalls(ctx context.Context, rw signatureRewrite) (map[protocol.DocumentURI][]byte, error) {
-	// tag is a unique prefix that is added to the delegated declaration.
-	//
-	// It must have a ~0% probability of causing collisions with existing names.
-	const tag = "G_o_p_l_s_"
-
 	var (
 		modifiedSrc  []byte
 		modifiedFile *ast.File
@@ -539,7 +882,7 @@ func rewriteCalls(ctx context.Context, rw signatureRewrite) (map[protocol.Docume
 	)
 	{
 		delegate := astutil.CloneNode(rw.newDecl) // clone before modifying
-		delegate.Name.Name = tag + delegate.Name.Name
+		delegate.Name.Name = delegateTag + delegate.Name.Name
 		if obj := rw.pkg.Types().Scope().Lookup(delegate.Name.Name); obj != nil {
 			return nil, fmt.Errorf("synthetic name %q conflicts with an existing declaration", delegate.Name.Name)
 		}
@@ -620,6 +963,12 @@ func rewriteCalls(ctx context.Context, rw signatureRewrite) (map[protocol.Docume
 		// by returning the modified AST from replaceDecl. Investigate if that is
 		// accurate.
 		modifiedSrc = append(modifiedSrc, []byte("\n\n"+FormatNode(fset, wrapper))...)
+		if len(rw.imports) > 0 {
+			modifiedSrc, err = addImports(modifiedSrc, rw.imports)
+			if err != nil {
+				return nil, err
+			}
+		}
 		modifiedFile, err = parser.ParseFile(rw.pkg.FileSet(), rw.pgf.URI.Path(), modifiedSrc, parser.ParseComments|parser.SkipObjectResolution)
 		if err != nil {
 			return nil, err
@@ -630,7 +979,7 @@ func rewriteCalls(ctx context.Context, rw signatureRewrite) (map[protocol.Docume
 	// Type check pkg again with the modified file, to compute the synthetic
 	// callee.
 	logf := logger(ctx, "change signature", rw.snapshot.Options().VerboseOutput)
-	pkg2, info, err := reTypeCheck(logf, rw.pkg, map[protocol.DocumentURI]*ast.File{rw.pgf.URI: modifiedFile}, false)
+	pkg2, info, err := reTypeCheck(logf, rw.pkg, map[protocol.DocumentURI]*ast.File{rw.pgf.URI: modifiedFile}, rw.imports, false)
 	if err != nil {
 		return nil, err
 	}
@@ -639,7 +988,7 @@ func rewriteCalls(ctx context.Context, rw signatureRewrite) (map[protocol.Docume
 		return nil, fmt.Errorf("analyzing callee: %v", err)
 	}
 
-	post := func(got []byte) []byte { return bytes.ReplaceAll(got, []byte(tag), nil) }
+	post := func(got []byte) []byte { return bytes.ReplaceAll(got, []byte(delegateTag), nil) }
 	opts := &inline.Options{
 		Logf:          logf,
 		IgnoreEffects: true,
@@ -649,13 +998,13 @@ func rewriteCalls(ctx context.Context, rw signatureRewrite) (map[protocol.Docume
 
 // reTypeCheck re-type checks orig with new file contents defined by fileMask.
 //
-// It expects that any newly added imports are already present in the
-// transitive imports of orig.
+// It expects that any newly added imports are either present in the
+// transitive imports of orig, or among the additional imports.
 //
 // If expectErrors is true, reTypeCheck allows errors in the new package.
 // TODO(rfindley): perhaps this should be a filter to specify which errors are
 // acceptable.
-func reTypeCheck(logf func(string, ...any), orig *cache.Package, fileMask map[protocol.DocumentURI]*ast.File, expectErrors bool) (*types.Package, *types.Info, error) {
+func reTypeCheck(logf func(string, ...any), orig *cache.Package, fileMask map[protocol.DocumentURI]*ast.File, imports []*types.Package, expectErrors bool) (*types.Package, *types.Info, error) {
 	pkg := types.NewPackage(string(orig.Metadata().PkgPath), string(orig.Metadata().Name))
 	info := &types.Info{
 		Types:        make(map[ast.Expr]types.TypeAndValue),
@@ -690,6 +1039,9 @@ func reTypeCheck(logf func(string, ...any), orig *cache.Package, fileMask map[pr
 				toSearch      = []*types.Package{orig.Types()}  // packages to search
 				searched      = make(map[string]bool)           // path -> (false, if present in toSearch; true, if already searched)
 			)
+			for _, p := range imports {
+				importsByPath[p.Path()] = p
+			}
 			importer = func(path string) (*types.Package, error) {
 				if p, ok := importsByPath[path]; ok {
 					return p, nil
@@ -754,22 +1106,6 @@ func reTypeCheck(logf func(string, ...any), orig *cache.Package, fileMask map[pr
 // TODO(golang/go#63472): this looks wrong with the new Go version syntax.
 var goVersionRx = regexp.MustCompile(`^go([1-9][0-9]*)\.(0|[1-9][0-9]*)$`)
 
-// selectElements returns a new array of elements of s indicated by the
-// provided list of indices. It returns false if any index was out of bounds.
-//
-// For example, given the slice []string{"a", "b", "c", "d"}, the
-// indices []int{3, 0, 1} results in the slice []string{"d", "a", "b"}.
-func selectElements[T any](s []T, indices []int) ([]T, bool) {
-	res := make([]T, len(indices))
-	for i, index := range indices {
-		if index < 0 || index >= len(s) {
-			return nil, false
-		}
-		res[i] = s[index]
-	}
-	return res, true
-}
-
 // replaceFileDecl replaces old with new in the file described by pgf.
 //
 // TODO(rfindley): generalize, and combine with rewriteSignature.
@@ -792,6 +1128,19 @@ func replaceFileDecl(pgf *parsego.File, old, new ast.Decl) ([]byte, error) {
 	return out.Bytes(), nil
 }
 
+// importDecls returns the number of import declarations of file, which
+// precede the other declarations.
+func importDecls(file *ast.File) int {
+	n := 0
+	for _, decl := range file.Decls {
+		if decl, ok := decl.(*ast.GenDecl); !ok || decl.Tok != token.IMPORT {
+			break
+		}
+		n++
+	}
+	return n
+}
+
 // findDecl finds the index of decl in file.Decls.
 //
 // TODO: use slices.Index when it is available.
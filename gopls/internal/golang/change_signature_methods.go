@@ -0,0 +1,521 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package golang
+
+// This file defines the part of the change signature operation that
+// adds parameters to the methods coupled, by interface satisfaction, to
+// the method whose signature changes, and to their calls.
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	goastutil "golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ast/edge"
+	"golang.org/x/tools/go/types/objectpath"
+	"golang.org/x/tools/go/types/typeutil"
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/cache/parsego"
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/protocol/command"
+	"golang.org/x/tools/gopls/internal/util/bug"
+	"golang.org/x/tools/gopls/internal/util/safetoken"
+	"golang.org/x/tools/internal/astutil"
+	"golang.org/x/tools/internal/diff"
+	"golang.org/x/tools/internal/refactor"
+	"golang.org/x/tools/refactor/satisfy"
+)
+
+// An insertedParam describes a parameter added to a method by inserting
+// it before the old parameter of a given index.
+type insertedParam struct {
+	*addedParam
+	before int        // index of the following old parameter, or the number of old parameters
+	typ    types.Type // type of the parameter
+}
+
+// paramInsertions returns the parameters added by a signature change,
+// or false if the change also removes or reorders parameters.
+func paramInsertions(newParams []command.ChangeSignatureParam, nold int, added map[int]*addedParam, newFields []flatField) ([]insertedParam, bool) {
+	var (
+		ins []insertedParam
+		old = 0
+	)
+	for i, p := range newParams {
+		if param, ok := added[i]; ok {
+			ins = append(ins, insertedParam{addedParam: param, before: old, typ: newFields[i].typ})
+		} else if p.OldIndex == old {
+			old++
+		} else {
+			return nil, false
+		}
+	}
+	return ins, old == nold
+}
+
+// addCoupledParams adds the parameters inserted into the method decl,
+// declared in pkg, to the methods coupled to it (see coupledMethods), and
+// the corresponding arguments to their calls, including dynamic calls
+// through interfaces. The arguments must not refer to the parameters of
+// decl, since they are not in scope at these calls.
+//
+// newContent holds the new contents of the files changed by the rest of
+// the signature change; addCoupledParams updates it.
+func addCoupledParams(ctx context.Context, snapshot *cache.Snapshot, pkg *cache.Package, pgf *parsego.File, decl *ast.FuncDecl, ins []insertedParam, imports []*types.Package, newContent map[protocol.DocumentURI][]byte) error {
+	fn, ok := pkg.TypesInfo().Defs[decl.Name].(*types.Func)
+	if !ok {
+		return bug.Errorf("no object for method %s", decl.Name.Name)
+	}
+	coupled, ids, err := coupledMethods(ctx, snapshot, pgf.URI, fn)
+	if err != nil {
+		return err
+	}
+	if len(coupled) == 0 {
+		return nil
+	}
+
+	// Type-check the packages that may refer to the coupled methods,
+	// after the rest of the change. (The methods no longer match, so these
+	// packages may have type errors.)
+	modified, release, err := snapshot.WithOverlays(ctx, newContent)
+	if err != nil {
+		return err
+	}
+	defer release()
+	pkgs, err := modified.TypeCheck(ctx, ids...)
+	if err != nil {
+		return err
+	}
+
+	args := argResolver{
+		pkg:     pkg.Types(),
+		scope:   pkg.TypesInfo().Scopes[decl.Type],
+		imports: imports,
+		fn:      fn,
+	}
+	done := make(map[protocol.DocumentURI]bool)
+	for _, p := range pkgs {
+		var enc objectpath.Encoder
+		isCoupled := func(obj types.Object) bool {
+			m, ok := obj.(*types.Func)
+			if !ok || m.Pkg() == nil {
+				return false
+			}
+			path, err := enc.For(m.Origin())
+			return err == nil && coupled[methodKey{PackagePath(m.Pkg().Path()), path}]
+		}
+		for _, pgf := range p.CompiledGoFiles() {
+			if done[pgf.URI] {
+				continue // a file of several package variants
+			}
+			done[pgf.URI] = true
+			edits, err := coupledEdits(p, pgf, isCoupled, ins, args)
+			if err != nil {
+				return err
+			}
+			if len(edits) == 0 {
+				continue
+			}
+			src, err := diff.ApplyBytes(pgf.Src, edits)
+			if err != nil {
+				return bug.Errorf("invalid edits to %s: %v", pgf.URI, err)
+			}
+			if src, err = format.Source(src); err != nil {
+				return bug.Errorf("adding parameters to coupled methods in %s: %v", pgf.URI, err)
+			}
+			newContent[pgf.URI] = src
+		}
+	}
+	return nil
+}
+
+// coupledEdits returns the edits to the file pgf of package pkg that add
+// the inserted parameters to the declarations of the coupled methods, and
+// the arguments to their calls.
+func coupledEdits(pkg *cache.Package, pgf *parsego.File, isCoupled func(types.Object) bool, ins []insertedParam, args argResolver) ([]diff.Edit, error) {
+	var (
+		info  = pkg.TypesInfo()
+		edits []diff.Edit
+	)
+	// The qualifier adds imports as needed.
+	prefixes := make(map[string]string)
+	qual := func(p *types.Package) string {
+		if p.Path() == pkg.Types().Path() {
+			return ""
+		}
+		prefix, ok := prefixes[p.Path()]
+		if !ok {
+			var edits2 []refactor.Edit
+			prefix, edits2 = refactor.AddImport(info, pgf.File, p.Name(), p.Path(), "", pgf.File.Name.End())
+			prefixes[p.Path()] = prefix
+			edits = append(edits, diffEdits(pgf.File, edits2)...)
+		}
+		return strings.TrimSuffix(prefix, ".")
+	}
+
+	// addParams adds the parameters to the declaration of a coupled method.
+	addParams := func(name string, ftype *ast.FuncType, nameErr func(string) error) error {
+		named := len(ftype.Params.List) == 0 || len(ftype.Params.List[0].Names) > 0
+		texts := make(map[int][]string) // new parameters, by index of the following one
+		for _, param := range ins {
+			text := types.TypeString(param.typ, qual)
+			if named {
+				pname := param.name
+				if pname == "" {
+					pname = "_"
+				} else if err := nameErr(pname); err != nil {
+					return fmt.Errorf("can't add parameter %s to the coupled method %s: %v", pname, name, err)
+				}
+				text = pname + " " + text
+			}
+			texts[param.before] = append(texts[param.before], text)
+		}
+		edit, err := insertParamsEdit(pgf, ftype.Params, texts)
+		if err != nil {
+			return err
+		}
+		edits = append(edits, edit)
+		return nil
+	}
+
+	for cur := range pgf.Cursor().Preorder((*ast.FuncDecl)(nil), (*ast.InterfaceType)(nil), (*ast.SelectorExpr)(nil)) {
+		switch n := cur.Node().(type) {
+		case *ast.FuncDecl:
+			if n.Recv != nil && isCoupled(info.Defs[n.Name]) {
+				err := addParams(n.Name.Name, n.Type, func(name string) error {
+					return checkParamName(info, n, name)
+				})
+				if err != nil {
+					return nil, err
+				}
+			}
+
+		case *ast.InterfaceType:
+			for _, field := range n.Methods.List {
+				ftype, ok := field.Type.(*ast.FuncType)
+				if !ok || len(field.Names) != 1 || !isCoupled(info.Defs[field.Names[0]]) {
+					continue
+				}
+				err := addParams(field.Names[0].Name, ftype, func(name string) error {
+					for _, param := range ftype.Params.List {
+						for _, id := range param.Names {
+							if id.Name == name {
+								return fmt.Errorf("%s is already declared", name)
+							}
+						}
+					}
+					return nil
+				})
+				if err != nil {
+					return nil, err
+				}
+			}
+
+		case *ast.SelectorExpr:
+			seln := info.Selections[n]
+			if seln == nil || !isCoupled(seln.Obj()) {
+				continue
+			}
+			if ek, _ := cur.ParentEdge(); seln.Kind() != types.MethodVal || ek != edge.CallExpr_Fun {
+				return nil, fmt.Errorf("can't add parameters to the coupled method %s: it is referenced by %s, which is not a call (at %s)",
+					seln.Obj().Name(), types.ExprString(n), safetoken.StartPosition(pkg.FileSet(), n.Pos()))
+			}
+			call := cur.Parent().Node().(*ast.CallExpr)
+			if len(call.Args) == 1 {
+				if tuple, ok := info.TypeOf(call.Args[0]).(*types.Tuple); ok && tuple.Len() > 1 {
+					return nil, fmt.Errorf("can't add arguments to the call %s, whose argument has several values", types.ExprString(call))
+				}
+			}
+			texts := make(map[int][]string) // new arguments, by index of the following one
+			for _, param := range ins {
+				text, err := args.text(pkg.Types(), param.arg, qual)
+				if err != nil {
+					return nil, err
+				}
+				texts[param.before] = append(texts[param.before], text)
+			}
+			for before, texts := range texts {
+				text := strings.Join(texts, ", ")
+				var pos token.Pos
+				switch {
+				case before < len(call.Args):
+					pos, text = call.Args[before].Pos(), text+", "
+				case len(call.Args) > 0:
+					pos, text = call.Args[len(call.Args)-1].End(), ", "+text
+				default:
+					pos = call.Lparen + 1
+				}
+				offset, err := safetoken.Offset(pgf.Tok, pos)
+				if err != nil {
+					return nil, err
+				}
+				edits = append(edits, diff.Edit{Start: offset, End: offset, New: text})
+			}
+		}
+	}
+	return edits, nil
+}
+
+// insertParamsEdit returns an edit that inserts the given parameters,
+// keyed by the index of the old parameter they precede, into the list
+// params. A group of parameters sharing a type, such as (a, b int), is
+// split if a parameter is inserted within it.
+func insertParamsEdit(pgf *parsego.File, params *ast.FieldList, texts map[int][]string) (diff.Edit, error) {
+	source := func(n ast.Node) (string, error) {
+		start, end, err := safetoken.Offsets(pgf.Tok, n.Pos(), n.End())
+		if err != nil {
+			return "", err
+		}
+		return string(pgf.Src[start:end]), nil
+	}
+	var (
+		parts []string
+		index = 0 // index of the current old parameter
+	)
+	for _, field := range params.List {
+		n := max(len(field.Names), 1)
+		split := false
+		for i := index + 1; i < index+n; i++ {
+			if len(texts[i]) > 0 {
+				split = true
+			}
+		}
+		if !split {
+			text, err := source(field)
+			if err != nil {
+				return diff.Edit{}, err
+			}
+			parts = append(parts, texts[index]...)
+			parts = append(parts, text)
+		} else {
+			typ, err := source(field.Type)
+			if err != nil {
+				return diff.Edit{}, err
+			}
+			for i, id := range field.Names {
+				parts = append(parts, texts[index+i]...)
+				parts = append(parts, id.Name+" "+typ)
+			}
+		}
+		index += n
+	}
+	parts = append(parts, texts[index]...)
+
+	start, end, err := safetoken.Offsets(pgf.Tok, params.Opening+1, params.Closing)
+	if err != nil {
+		return diff.Edit{}, err
+	}
+	return diff.Edit{Start: start, End: end, New: strings.Join(parts, ", ")}, nil
+}
+
+// An argResolver translates the argument for an added parameter, an
+// expression in the scope of the changed function fn, for use in other
+// files.
+type argResolver struct {
+	pkg     *types.Package   // package of fn
+	scope   *types.Scope     // scope of fn's parameters
+	imports []*types.Package // packages newly imported for the argument
+	fn      *types.Func
+}
+
+// text returns the text of the argument arg in a file of package pkg,
+// qualifying its references to other packages using qual.
+func (r argResolver) text(pkg *types.Package, arg ast.Expr, qual types.Qualifier) (string, error) {
+	var err error
+	arg = r.resolve(pkg, astutil.CloneNode(arg), qual, &err)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := format.Node(&buf, token.NewFileSet(), arg); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// resolve returns the expression e, modified for use in pkg: its package
+// qualifiers are those of qual, and its references to the package-level
+// declarations of the package of fn are qualified if need be. It reports
+// an error if e refers to a local declaration of fn.
+func (r argResolver) resolve(pkg *types.Package, e ast.Expr, qual types.Qualifier, errp *error) ast.Expr {
+	return goastutil.Apply(e, func(c *goastutil.Cursor) bool {
+		if *errp != nil {
+			return false
+		}
+		switch n := c.Node().(type) {
+		case *ast.SelectorExpr:
+			if id, ok := n.X.(*ast.Ident); ok {
+				if p := r.pkgName(id.Name); p != nil {
+					if name := qual(p); name != "" {
+						id.Name = name
+					} else {
+						c.Replace(n.Sel) // a member of pkg, or dot-imported
+					}
+					return false
+				}
+			}
+			n.X = r.resolve(pkg, n.X, qual, errp)
+			return false // don't resolve the field or method name
+
+		case *ast.KeyValueExpr:
+			if _, ok := n.Key.(*ast.Ident); ok {
+				n.Value = r.resolve(pkg, n.Value, qual, errp)
+				return false // don't resolve the field name
+			}
+
+		case *ast.Ident:
+			_, obj := r.scope.LookupParent(n.Name, token.NoPos)
+			switch {
+			case obj == nil, obj.Parent() == types.Universe:
+				// A predeclared identifier, or one declared within e.
+			case obj.Pkg() == r.pkg && obj.Parent() == r.pkg.Scope():
+				if pkg.Path() != r.pkg.Path() {
+					if !obj.Exported() {
+						*errp = fmt.Errorf("can't add the argument for a new parameter to calls of %s outside package %s: it refers to the unexported %s", r.fn.Name(), r.pkg.Name(), n.Name)
+						return false
+					}
+					c.Replace(&ast.SelectorExpr{X: ast.NewIdent(qual(r.pkg)), Sel: ast.NewIdent(n.Name)})
+				}
+			default:
+				*errp = fmt.Errorf("can't add the argument for a new parameter to calls through interfaces: it refers to %s, which is local to %s", n.Name, r.fn.Name())
+				return false
+			}
+		}
+		return true
+	}, nil).(ast.Expr)
+}
+
+// pkgName returns the package denoted by the given name in the scope of
+// fn, or nil.
+func (r argResolver) pkgName(name string) *types.Package {
+	if _, obj := r.scope.LookupParent(name, token.NoPos); obj != nil {
+		if pkgName, ok := obj.(*types.PkgName); ok {
+			return pkgName.Imported()
+		}
+		return nil
+	}
+	for _, p := range r.imports {
+		if p.Name() == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// A methodKey identifies a method across type-checkings of its package.
+type methodKey struct {
+	pkgPath PackagePath
+	path    objectpath.Path
+}
+
+// coupledMethods returns the methods that must change signature along
+// with the concrete method fn, declared in the file declURI, for the
+// types of the workspace to satisfy the same interfaces: the methods of
+// the interfaces to which the receiver type is assigned, those of the
+// other types assigned to these interfaces, and so on. It also returns
+// the packages that may refer to these methods.
+func coupledMethods(ctx context.Context, snapshot *cache.Snapshot, declURI protocol.DocumentURI, fn *types.Func) (map[methodKey]bool, []PackageID, error) {
+	key := func(m *types.Func) (methodKey, bool) {
+		path, err := objectpath.For(m.Origin())
+		return methodKey{PackagePath(m.Pkg().Path()), path}, err == nil
+	}
+	fnKey, ok := key(fn)
+	if !ok {
+		return nil, nil, bug.Errorf("no object path for method %s", fn)
+	}
+	type constraint struct {
+		satisfy.Constraint
+		fset *token.FileSet
+	}
+	var (
+		coupled     = map[methodKey]bool{fnKey: true}
+		constraints []constraint
+		msets       typeutil.MethodSetCache
+		ids         []PackageID
+		seenPkgs    = make(map[PackageID]bool)
+		seenFiles   = make(map[protocol.DocumentURI]bool)
+		files       = []protocol.DocumentURI{declURI} // files declaring coupled methods
+	)
+	for len(files) > 0 {
+		uri := files[0]
+		files = files[1:]
+		if seenFiles[uri] {
+			continue
+		}
+		seenFiles[uri] = true
+
+		// Find the satisfaction constraints of the packages that may
+		// refer to the methods declared in the file.
+		pkgs, err := typeCheckReverseDependencies(ctx, snapshot, uri, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, p := range pkgs {
+			id := p.Metadata().ID
+			if seenPkgs[id] {
+				continue
+			}
+			seenPkgs[id] = true
+			if len(p.ParseErrors()) > 0 || len(p.TypeErrors()) > 0 {
+				return nil, nil, fmt.Errorf("can't find the methods coupled to %s: package %s has errors", fn.Name(), p.Metadata().PkgPath)
+			}
+			ids = append(ids, id)
+			var f satisfy.Finder
+			f.Find(p.TypesInfo(), p.Syntax())
+			for c := range f.Result {
+				constraints = append(constraints, constraint{c, p.FileSet()})
+			}
+		}
+
+		// Couple the methods of each constraint, until a fixed point.
+		for changed := true; changed; {
+			changed = false
+			for _, c := range constraints {
+				lsel := msets.MethodSet(c.LHS).Lookup(fn.Pkg(), fn.Name())
+				rsel := msets.MethodSet(c.RHS).Lookup(fn.Pkg(), fn.Name())
+				if lsel == nil || rsel == nil {
+					continue
+				}
+				pair := []*types.Func{lsel.Obj().(*types.Func), rsel.Obj().(*types.Func)}
+				if !slices.ContainsFunc(pair, func(m *types.Func) bool {
+					mkey, ok := key(m)
+					return ok && coupled[mkey]
+				}) {
+					continue
+				}
+				for _, m := range pair {
+					mkey, ok := key(m)
+					if !ok {
+						return nil, nil, fmt.Errorf("can't add parameters to %s: it must match the method %s of an unnamed interface type", fn.Name(), m.Name())
+					}
+					if coupled[mkey] {
+						continue
+					}
+					muri := protocol.URIFromPath(safetoken.StartPosition(c.fset, m.Pos()).Filename)
+					mps, err := snapshot.MetadataForFile(ctx, muri, true)
+					if err != nil {
+						return nil, nil, err
+					}
+					if len(mps) == 0 || !snapshot.IsWorkspacePackage(mps[0].ID) {
+						return nil, nil, fmt.Errorf("can't add parameters to %s: it must match the method %s of %s, which is not in the workspace",
+							fn.Name(), m.Name(), types.TypeString(m.Signature().Recv().Type(), nil))
+					}
+					coupled[mkey] = true
+					changed = true
+					files = append(files, muri)
+				}
+			}
+		}
+	}
+	delete(coupled, fnKey)
+	return coupled, ids, nil
+}
@@ -249,6 +249,7 @@ var codeActionProducers = [...]codeActionProducer{
 	{kind: settings.GoToggleCompilerOptDetails, fn: toggleCompilerOptDetails},
 	{kind: settings.RefactorExtractFunction, fn: refactorExtractFunction},
 	{kind: settings.RefactorExtractMethod, fn: refactorExtractMethod},
+	{kind: settings.RefactorExtractInterface, fn: refactorExtractInterface, needPkg: true},
 	{kind: settings.RefactorExtractToNewFile, fn: refactorExtractToNewFile},
 	{kind: settings.RefactorExtractConstant, fn: refactorExtractVariable, needPkg: true},
 	{kind: settings.RefactorExtractVariable, fn: refactorExtractVariable, needPkg: true},
@@ -263,10 +264,16 @@ var codeActionProducers = [...]codeActionProducer{
 	{kind: settings.RefactorRewriteFillSwitch, fn: refactorRewriteFillSwitch, needPkg: true},
 	{kind: settings.RefactorRewriteImplementInterface, fn: refactorRewriteImplementInterface, needPkg: true},
 	{kind: settings.RefactorRewriteInvertIf, fn: refactorRewriteInvertIf},
+	{kind: settings.RefactorRewriteIfToSwitch, fn: refactorRewriteIfToSwitch, needPkg: true},
+	{kind: settings.RefactorRewriteSwitchToIf, fn: refactorRewriteSwitchToIf, needPkg: true},
 	{kind: settings.RefactorRewriteJoinLines, fn: refactorRewriteJoinLines, needPkg: true},
 	{kind: settings.RefactorRewriteRemoveUnusedParam, fn: refactorRewriteRemoveUnusedParam, needPkg: true},
 	{kind: settings.RefactorRewriteMoveParamLeft, fn: refactorRewriteMoveParamLeft, needPkg: true},
 	{kind: settings.RefactorRewriteMoveParamRight, fn: refactorRewriteMoveParamRight, needPkg: true},
+	{kind: settings.RefactorRewriteAddParam, fn: refactorRewriteAddParam, needPkg: true},
+	{kind: settings.RefactorRewriteFuncToMethod, fn: refactorRewriteFuncToMethod, needPkg: true},
+	{kind: settings.RefactorRewriteMethodToFunc, fn: refactorRewriteMethodToFunc, needPkg: true},
+	{kind: settings.RefactorRewriteParamStruct, fn: refactorRewriteParamStruct, needPkg: true},
 	{kind: settings.RefactorRewriteSplitLines, fn: refactorRewriteSplitLines, needPkg: true},
 	{kind: settings.RefactorRewriteEliminateDotImport, fn: refactorRewriteEliminateDotImport, needPkg: true},
 	{kind: settings.RefactorRewriteAddTags, fn: refactorRewriteAddStructTags, needPkg: true},
@@ -683,6 +690,87 @@ func refactorRewriteMoveParamRight(ctx context.Context, req *codeActionsRequest)
 	return nil
 }
 
+// refactorRewriteAddParam produces "Add parameter" code actions.
+// See [server.commandHandler.ChangeSignature] for command implementation.
+func refactorRewriteAddParam(ctx context.Context, req *codeActionsRequest) error {
+	// The user specifies the new parameter in a form.
+	if !supportsDialog(req.snapshot.Options().ClientOptions, addParamForm) {
+		return nil
+	}
+	info := findParam(req.pgf, req.loc.Range)
+	if info == nil || info.decl.Body == nil {
+		return nil
+	}
+	// The selection must be within the name or the parameters.
+	if req.start < info.decl.Name.Pos() || req.end > info.decl.Type.Params.End() {
+		return nil
+	}
+	if info.decl.Recv == nil && (info.decl.Name.Name == "init" || info.decl.Name.Name == "main" && req.pkg.Types().Name() == "main") {
+		return nil
+	}
+
+	// The new parameter follows the selected one, or comes first.
+	params := info.decl.Type.Params
+	at := 0
+	if info.paramIndex >= 0 {
+		if is[*ast.Ellipsis](info.field.Type) {
+			return nil // the variadic parameter must be last
+		}
+		at = info.paramIndex + 1
+	}
+	transform := identityTransform(params)
+	transform = slices.Insert(transform, at, command.ChangeSignatureParam{NewField: "newParam int"})
+	cmd := command.NewChangeSignatureCommand("Add parameter", command.ChangeSignatureArgs{
+		Location:   req.loc,
+		NewParams:  transform,
+		NewResults: identityTransform(info.decl.Type.Results),
+	})
+	req.addCommandAction(cmd, false)
+	return nil
+}
+
+// refactorRewriteFuncToMethod produces "Convert function F to method of T"
+// code actions.
+// See [server.commandHandler.ConvertFunc] for command implementation.
+func refactorRewriteFuncToMethod(ctx context.Context, req *codeActionsRequest) error {
+	if conv, err := convertibleFunc(req.pkg, req.pgf, req.start, req.end); err == nil && conv.toMethod() {
+		title := fmt.Sprintf("Convert function %s to method of %s", conv.fn.Name(), conv.named.Obj().Name())
+		req.addCommandAction(command.NewConvertFuncCommand(title, command.ConvertFuncArgs{
+			Location:     req.loc,
+			ResolveEdits: req.resolveEdits(),
+		}), true)
+	}
+	return nil
+}
+
+// refactorRewriteMethodToFunc produces "Convert method M to function"
+// code actions.
+// See [server.commandHandler.ConvertFunc] for command implementation.
+func refactorRewriteMethodToFunc(ctx context.Context, req *codeActionsRequest) error {
+	if conv, err := convertibleFunc(req.pkg, req.pgf, req.start, req.end); err == nil && !conv.toMethod() {
+		title := fmt.Sprintf("Convert method %s to function", conv.fn.Name())
+		req.addCommandAction(command.NewConvertFuncCommand(title, command.ConvertFuncArgs{
+			Location:     req.loc,
+			ResolveEdits: req.resolveEdits(),
+		}), true)
+	}
+	return nil
+}
+
+// refactorRewriteParamStruct produces "Introduce parameter
+// struct" code actions.
+// See [server.commandHandler.IntroduceParamStruct] for command implementation.
+func refactorRewriteParamStruct(ctx context.Context, req *codeActionsRequest) error {
+	if ps, err := selectedParamStruct(req.pkg, req.pgf, req.start, req.end); err == nil {
+		title := fmt.Sprintf("Introduce parameter struct %s", ps.name)
+		req.addCommandAction(command.NewIntroduceParamStructCommand(title, command.IntroduceParamStructArgs{
+			Location:     req.loc,
+			ResolveEdits: req.resolveEdits(),
+		}), true)
+	}
+	return nil
+}
+
 // refactorRewriteChangeQuote produces "Convert to {raw,interpreted} string literal" code actions.
 func refactorRewriteChangeQuote(ctx context.Context, req *codeActionsRequest) error {
 	convertStringLiteral(req)
@@ -698,6 +786,26 @@ func refactorRewriteInvertIf(ctx context.Context, req *codeActionsRequest) error
 	return nil
 }
 
+// refactorRewriteIfToSwitch produces "Convert if/else-if chain to switch"
+// code actions.
+// See [ifToSwitch] for command implementation.
+func refactorRewriteIfToSwitch(ctx context.Context, req *codeActionsRequest) error {
+	if _, err := canIfToSwitch(req.pgf.Cursor(), req.start, req.end); err == nil {
+		req.addApplyFixAction("Convert if/else-if chain to switch", fixIfToSwitch, req.loc)
+	}
+	return nil
+}
+
+// refactorRewriteSwitchToIf produces "Convert switch to if/else-if chain"
+// code actions.
+// See [switchToIf] for command implementation.
+func refactorRewriteSwitchToIf(ctx context.Context, req *codeActionsRequest) error {
+	if _, err := canSwitchToIf(req.pgf.Cursor(), req.start, req.end); err == nil {
+		req.addApplyFixAction("Convert switch to if/else-if chain", fixSwitchToIf, req.loc)
+	}
+	return nil
+}
+
 // refactorRewriteSplitLines produces "Split ITEMS into separate lines" code actions.
 // See [splitLines] for command implementation.
 func refactorRewriteSplitLines(ctx context.Context, req *codeActionsRequest) error {
@@ -1027,6 +1135,41 @@ func refactorRewriteImplementInterface(_ context.Context, req *codeActionsReques
 	return nil
 }
 
+// refactorExtractInterface produces "Extract interface" code actions.
+// See [server.commandHandler.ExtractInterface] for command implementation.
+func refactorExtractInterface(_ context.Context, req *codeActionsRequest) error {
+	// The interface is described by the user through a dialog.
+	if !supportsDialog(req.snapshot.Options().ClientOptions, extractInterfaceForm) {
+		return nil
+	}
+
+	start, end, err := req.pgf.RangePos(req.loc.Range)
+	if err != nil {
+		return err
+	}
+	cur, _, _, _ := astutil.Select(req.pgf.Cursor(), start, end) // can't fail
+	named, err := extractInterfaceType(req.pkg, req.pgf, cur)
+	if err != nil {
+		return nil // no type from which to extract an interface
+	}
+
+	var methods []string
+	for _, m := range exportedMethods(named) {
+		methods = append(methods, m.Name())
+	}
+	cmd := command.NewExtractInterfaceCommand(
+		fmt.Sprintf("Extract interface from %s...", named.Obj().Name()),
+		command.ExtractInterfaceArgs{
+			Location: req.loc,
+			Name:     named.Obj().Name() + "Interface",
+			Methods:  methods,
+			Package:  named.Obj().Pkg().Path(),
+		},
+	)
+	req.addCommandAction(cmd, false)
+	return nil
+}
+
 // removableParameter returns paramInfo about a removable parameter indicated
 // by the given [start, end) range, or nil if no such removal is available.
 //
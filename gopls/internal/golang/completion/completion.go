@@ -26,6 +26,7 @@ import (
 	"unicode/utf8"
 
 	goastutil "golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/types/objectpath"
 	"golang.org/x/tools/gopls/internal/cache"
 	"golang.org/x/tools/gopls/internal/cache/metadata"
 	"golang.org/x/tools/gopls/internal/cache/parsego"
@@ -103,6 +104,15 @@ type CompletionItem struct {
 	// Documentation is the documentation for the completion item.
 	Documentation string
 
+	// Resolve, if non-nil, describes the properties of the item whose
+	// computation was deferred until the client resolves it.
+	// See [Resolve].
+	Resolve *ResolveData
+
+	// importPath is the path of the package that must be imported
+	// for this completion, if any.
+	importPath string
+
 	// isSlice reports whether the underlying type of the object
 	// from which this candidate was derived is a slice.
 	// (Used to complete append() calls.)
@@ -120,6 +130,10 @@ type completionOptions struct {
 	matcher               settings.Matcher
 	budget                time.Duration
 	completeFunctionCalls bool
+
+	// Properties computed only when the client resolves the item.
+	resolveDocumentation bool
+	resolveImports       bool
 }
 
 // Snippet is a convenience returns the snippet if available, otherwise
@@ -277,6 +291,10 @@ type completer struct {
 	// the version of Go in force in the completion file.
 	tooNewSymbolsCache map[*types.Package]map[types.Object]stdlib.Symbol
 
+	// objectPaths encodes the object paths of the candidates whose
+	// documentation is resolved later.
+	objectPaths objectpath.Encoder
+
 	// mapper converts the positions in the file from which the completion originated.
 	mapper *protocol.Mapper
 
@@ -641,6 +659,8 @@ func Completion(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle, p
 			snippets:              opts.InsertTextFormat == protocol.SnippetTextFormat,
 			postfix:               opts.ExperimentalPostfixCompletions,
 			completeFunctionCalls: opts.CompleteFunctionCalls,
+			resolveDocumentation:  slices.Contains(opts.CompletionResolveOptions, "documentation"),
+			resolveImports:        slices.Contains(opts.CompletionResolveOptions, "additionalTextEdits"),
 		},
 		// default to a matcher that always matches
 		matcher:            prefixMatcher(""),
@@ -181,15 +181,8 @@ Suffixes:
 	}
 
 	// If this candidate needs an additional import statement,
-	// add the additional text edits needed.
+	// note the package in the detail. (The edits are added below.)
 	if cand.imp != nil {
-		addlEdits, err := c.importEdits(cand.imp)
-
-		if err != nil {
-			return CompletionItem{}, err
-		}
-
-		protocolEdits = append(protocolEdits, addlEdits...)
 		if kind != protocol.ModuleCompletion {
 			if detail != "" {
 				detail += " "
@@ -253,6 +246,9 @@ Suffixes:
 		snippet:             &snip,
 		isSlice:             isSlice(obj),
 	}
+	if err := c.addImport(&item, cand.imp); err != nil {
+		return CompletionItem{}, err
+	}
 	// If the user doesn't want documentation for completion items.
 	if !c.opts.documentation {
 		return item, nil
@@ -265,6 +261,23 @@ Suffixes:
 		return item, nil
 	}
 
+	// If the client can resolve the documentation, just record how to
+	// find the object: reading and parsing the declaring file is
+	// expensive when there are many candidates. Objects that have no
+	// object path, such as local variables, are declared in the current
+	// package, so their documentation is computed now.
+	if c.opts.resolveDocumentation {
+		if is[*types.TypeName](obj) && is[*types.TypeParam](obj.Type()) {
+			return item, nil // type parameters have no documentation
+		}
+		if path, err := c.objectPaths.For(obj); err == nil {
+			data := c.resolveData(&item)
+			data.PkgPath = obj.Pkg().Path()
+			data.ObjectPath = string(path)
+			return item, nil
+		}
+	}
+
 	comment, err := golang.HoverDocForObject(ctx, c.snapshot, c.pkg.FileSet(), obj)
 	if err != nil {
 		event.Error(ctx, fmt.Sprintf("failed to find Hover for %q", obj.Name()), err)
@@ -318,6 +331,36 @@ func (c *completer) formatConversion(convertTo types.Type) conversionEdits {
 	return conversionEdits{prefix: typeName + "(", suffix: ")"}
 }
 
+// addImport records that the item requires the given import, if
+// non-nil, and adds the edits to add the import to the current file,
+// unless the client can resolve them later.
+func (c *completer) addImport(item *CompletionItem, imp *importInfo) error {
+	if imp == nil {
+		return nil
+	}
+	item.importPath = imp.importPath
+	if c.opts.resolveImports {
+		data := c.resolveData(item)
+		data.ImportPath = imp.importPath
+		data.ImportName = imp.name
+		return nil
+	}
+	edits, err := c.importEdits(imp)
+	if err != nil {
+		return err
+	}
+	item.AdditionalTextEdits = append(item.AdditionalTextEdits, edits...)
+	return nil
+}
+
+// resolveData returns the ResolveData of the item, creating it if necessary.
+func (c *completer) resolveData(item *CompletionItem) *ResolveData {
+	if item.Resolve == nil {
+		item.Resolve = &ResolveData{URI: c.fh.URI()}
+	}
+	return item.Resolve
+}
+
 // importEdits produces the text edits necessary to add the given import to the current file.
 func (c *completer) importEdits(imp *importInfo) ([]protocol.TextEdit, error) {
 	if imp == nil {
@@ -0,0 +1,139 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package completion
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/doc"
+
+	"golang.org/x/tools/go/types/objectpath"
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/cache/metadata"
+	"golang.org/x/tools/gopls/internal/golang"
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/settings"
+	internalastutil "golang.org/x/tools/internal/astutil"
+	"golang.org/x/tools/internal/event"
+	"golang.org/x/tools/internal/imports"
+)
+
+// ResolveData holds the information needed to compute the properties
+// of a completion item that are deferred until the client resolves
+// it (see completionItem/resolve). It is sent to the client as the
+// item's data, so it must be serializable as JSON.
+//
+// Computing documentation requires reading and parsing the file that
+// declares each candidate, and computing import edits requires
+// reformatting the import declarations of the current file. Both are
+// expensive when there are many candidates, as in deep completion in
+// a large workspace, yet only a few items are ever displayed in
+// detail or accepted.
+type ResolveData struct {
+	// URI is the file in which completion was requested.
+	URI protocol.DocumentURI `json:"uri"`
+
+	// PkgPath and ObjectPath identify the candidate, if its
+	// documentation is to be resolved. Unlike a position, they remain
+	// valid after edits to the declaring file.
+	PkgPath    string `json:"pkgPath,omitempty"`
+	ObjectPath string `json:"objectPath,omitempty"`
+
+	// ImportPath and ImportName describe the import required by the
+	// candidate, if its import edits are to be resolved.
+	ImportPath string `json:"importPath,omitempty"`
+	ImportName string `json:"importName,omitempty"`
+}
+
+// Resolve computes the deferred properties of a completion item
+// described by data: its documentation and deprecation status, and
+// the edits needed to add its import to the file in which completion
+// was requested. Only these fields of the result are populated.
+func Resolve(ctx context.Context, snapshot *cache.Snapshot, data *ResolveData) (*CompletionItem, error) {
+	ctx, done := event.Start(ctx, "completion.Resolve")
+	defer done()
+
+	var item CompletionItem
+	opts := snapshot.Options()
+	if data.ObjectPath != "" {
+		// Failure to find the documentation doesn't prevent the
+		// resolution of the import edits.
+		comment, err := resolveDoc(ctx, snapshot, data)
+		if err != nil {
+			event.Error(ctx, "resolving completion documentation", err)
+		}
+		if comment != nil {
+			if opts.HoverKind == settings.FullDocumentation {
+				item.Documentation = comment.Text()
+			} else {
+				item.Documentation = doc.Synopsis(comment.Text())
+			}
+			if internalastutil.Deprecation(comment) != "" {
+				if opts.CompletionTags {
+					item.Tags = []protocol.CompletionItemTag{protocol.ComplDeprecated}
+				} else if opts.CompletionDeprecated {
+					item.Deprecated = true
+				}
+			}
+		}
+	}
+	if data.ImportPath != "" {
+		fh, err := snapshot.ReadFile(ctx, data.URI)
+		if err != nil {
+			return nil, err
+		}
+		src, err := fh.Content()
+		if err != nil {
+			return nil, err
+		}
+		item.AdditionalTextEdits, err = golang.ComputeImportFixEdits(opts.Local, src, &imports.ImportFix{
+			StmtInfo: imports.ImportInfo{
+				ImportPath: data.ImportPath,
+				Name:       data.ImportName,
+			},
+			FixType: imports.AddImport,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &item, nil
+}
+
+// resolveDoc returns the doc comment of the candidate identified by
+// data. It prefers the variant of the candidate's package among the
+// dependencies of the file in which completion was requested, as that
+// is the one from which the candidate was obtained.
+func resolveDoc(ctx context.Context, snapshot *cache.Snapshot, data *ResolveData) (*ast.CommentGroup, error) {
+	mp, err := snapshot.NarrowestMetadataForFile(ctx, data.URI)
+	if err != nil {
+		return nil, err
+	}
+	var id metadata.PackageID
+	for dep := range snapshot.MetadataGraph().ForwardReflexiveTransitiveClosure(mp.ID) {
+		if string(dep.PkgPath) == data.PkgPath {
+			id = dep.ID
+			break
+		}
+	}
+	if id == "" {
+		// The candidate is from a package that is not yet imported.
+		mps := snapshot.MetadataGraph().ForPackagePath[metadata.PackagePath(data.PkgPath)]
+		if len(mps) == 0 {
+			return nil, fmt.Errorf("no package %s", data.PkgPath)
+		}
+		id = mps[0].ID
+	}
+	pkgs, err := snapshot.TypeCheck(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := objectpath.Object(pkgs[0].Types(), objectpath.Path(data.ObjectPath))
+	if err != nil {
+		return nil, err
+	}
+	return golang.HoverDocForObject(ctx, snapshot, pkgs[0].FileSet(), obj)
+}
@@ -103,17 +103,12 @@ func (c *completer) filterGoMod(ctx context.Context, items []CompletionItem) []C
 	}
 	better := []CompletionItem{}
 	for _, compl := range items {
-		if len(compl.AdditionalTextEdits) == 0 {
-			continue
-		}
 		// import "foof/pkg"
-		flds := strings.FieldsFunc(compl.AdditionalTextEdits[0].NewText, func(r rune) bool {
-			return r == '"' || r == '/'
-		})
-		if len(flds) < 3 {
+		first, _, ok := strings.Cut(compl.importPath, "/")
+		if !ok {
 			continue
 		}
-		if slices.Contains(reqnames, flds[1]) {
+		if slices.Contains(reqnames, first) {
 			better = append(better, compl)
 		}
 	}
@@ -359,7 +354,7 @@ func (c *completer) appendNewItem(got []CompletionItem, name, detail string, pat
 	if imports.ImportPathToAssumedName(string(path)) == string(pkg) {
 		imp.name = ""
 	}
-	item.AdditionalTextEdits, _ = c.importEdits(&imp)
+	_ = c.addImport(&item, &imp)
 	if params != nil {
 		var sn snippet.Builder
 		c.functionCallSnippet(name, nil, params, &sn)
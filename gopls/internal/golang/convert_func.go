@@ -0,0 +1,607 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package golang
+
+// This file defines the operations that convert a function to a method
+// of the type of its first parameter, and a method to a function whose
+// first parameter is the receiver.
+//
+// Both use a variant of the approach of change signature (see
+// change_signature.go): as a function F and a method T.F may coexist,
+// we first declare the converted function alongside a wrapper, the
+// original function with a body that delegates to the converted one:
+//
+//	func (r *T) F(a int) int { ...original body... }
+//
+//	func F(r *T, a int) int { return r.F(a) }
+//
+// and then inline all calls of the wrapper, and delete it.
+// References to the function in non-call positions are replaced by
+// method expressions, such as (*T).F, or vice versa if possible, and
+// otherwise by function literals (see etaAbstract).
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	goastutil "golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/types/typeutil"
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/cache/parsego"
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/util/bug"
+	"golang.org/x/tools/gopls/internal/util/safetoken"
+	"golang.org/x/tools/internal/astutil"
+	"golang.org/x/tools/internal/diff"
+	"golang.org/x/tools/internal/refactor/inline"
+	"golang.org/x/tools/internal/typesinternal"
+	"golang.org/x/tools/refactor/satisfy"
+)
+
+// A funcConversion describes the conversion of a function to a method,
+// or of a method to a function.
+type funcConversion struct {
+	decl  *ast.FuncDecl
+	fn    *types.Func
+	named *types.Named // the receiver type, or the type of the first parameter
+}
+
+// toMethod reports whether the conversion is from a function to a method.
+func (c *funcConversion) toMethod() bool { return c.decl.Recv == nil }
+
+// convertibleFunc returns the conversion of the function or method
+// whose declaration header (from the func keyword to its parameters)
+// encloses [start, end), or an error if there is none.
+func convertibleFunc(pkg *cache.Package, pgf *parsego.File, start, end token.Pos) (*funcConversion, error) {
+	path, _ := goastutil.PathEnclosingInterval(pgf.File, start, end)
+	var decl *ast.FuncDecl
+	for _, n := range path {
+		if d, ok := n.(*ast.FuncDecl); ok {
+			decl = d
+			break
+		}
+	}
+	if decl == nil || end > decl.Type.Params.End() {
+		return nil, fmt.Errorf("no function declaration")
+	}
+	fn, ok := pkg.TypesInfo().Defs[decl.Name].(*types.Func)
+	if !ok || decl.Body == nil {
+		return nil, fmt.Errorf("%s has no body", decl.Name.Name)
+	}
+	if decl.Name.Name == "_" {
+		return nil, fmt.Errorf("cannot convert a blank function")
+	}
+	if decl.Type.TypeParams != nil {
+		return nil, fmt.Errorf("cannot convert generic function %s", fn.Name())
+	}
+
+	// validReceiver reports the named type of a valid receiver of type t.
+	validReceiver := func(t types.Type) (*types.Named, bool) {
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		named, ok := types.Unalias(t).(*types.Named)
+		if !ok || named.Obj().Pkg() != pkg.Types() || !typesinternal.IsPackageLevel(named.Obj()) ||
+			named.TypeParams().Len() > 0 || is[*types.Pointer](named.Underlying()) || types.IsInterface(named) {
+			return nil, false
+		}
+		return named, true
+	}
+
+	conv := &funcConversion{decl: decl, fn: fn}
+	if decl.Recv == nil {
+		// Function to method.
+		params := fn.Signature().Params()
+		if params.Len() == 0 {
+			return nil, fmt.Errorf("%s has no parameters", fn.Name())
+		}
+		recv := params.At(0)
+		named, ok := validReceiver(recv.Type())
+		if !ok {
+			return nil, fmt.Errorf("cannot declare methods on the type %s of the first parameter of %s",
+				types.TypeString(recv.Type(), types.RelativeTo(pkg.Types())), fn.Name())
+		}
+		if obj, _, _ := types.LookupFieldOrMethod(recv.Type(), true, pkg.Types(), fn.Name()); obj != nil {
+			return nil, fmt.Errorf("%s already has a %s %s", named.Obj().Name(), typesinternal.ObjectKind(obj), fn.Name())
+		}
+		conv.named = named
+	} else {
+		// Method to function.
+		_, named := typesinternal.ReceiverNamed(fn.Signature().Recv())
+		if named == nil {
+			return nil, bug.Errorf("no receiver type for method %s", fn.Name())
+		}
+		if _, ok := validReceiver(named); !ok {
+			return nil, fmt.Errorf("cannot convert method %s of generic type %s", fn.Name(), named.Obj().Name())
+		}
+		if obj := pkg.Types().Scope().Lookup(fn.Name()); obj != nil {
+			return nil, fmt.Errorf("package %s already declares %s", pkg.Types().Name(), fn.Name())
+		}
+		if obj := types.Universe.Lookup(fn.Name()); obj != nil {
+			return nil, fmt.Errorf("a function %s would shadow the predeclared %s", fn.Name(), fn.Name())
+		}
+		for _, file := range pkg.Syntax() {
+			if obj := pkg.TypesInfo().Scopes[file].Lookup(fn.Name()); obj != nil {
+				return nil, fmt.Errorf("a function %s would conflict with the import of %s", fn.Name(), obj.Name())
+			}
+		}
+		conv.named = named
+	}
+	return conv, nil
+}
+
+// ConvertFunc converts the function whose declaration header encloses
+// rng to a method of the type of its first parameter, or the method to a
+// function whose first parameter is its receiver, and updates all its
+// references.
+func ConvertFunc(ctx context.Context, snapshot *cache.Snapshot, pkg *cache.Package, pgf *parsego.File, rng protocol.Range) ([]protocol.DocumentChange, error) {
+	if err := checkPackageErrors(pkg, "convert functions"); err != nil {
+		return nil, err
+	}
+	start, end, err := pgf.RangePos(rng)
+	if err != nil {
+		return nil, err
+	}
+	conv, err := convertibleFunc(pkg, pgf, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if !conv.toMethod() {
+		if err := checkMethodUnneeded(ctx, snapshot, pgf.URI, conv.fn); err != nil {
+			return nil, fmt.Errorf("cannot convert method %s to a function: %v", conv.fn.Name(), err)
+		}
+	}
+
+	// Step 1: declare the converted function in place of the original
+	// one, and append the wrapper.
+	newContent := make(map[protocol.DocumentURI][]byte)
+	{
+		header, wrapper, err := conv.declarations(pgf)
+		if err != nil {
+			return nil, err
+		}
+		start, end, err := safetoken.Offsets(pgf.Tok, conv.decl.Pos(), conv.decl.Type.Params.Closing+1)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		buf.Write(pgf.Src[:start])
+		buf.WriteString(header)
+		buf.Write(bytes.TrimRight(pgf.Src[end:], "\n"))
+		buf.WriteString("\n\n")
+		buf.WriteString(wrapper)
+		buf.WriteString("\n")
+		newContent[pgf.URI] = buf.Bytes()
+	}
+
+	// Step 2: replace the references to the wrapper in non-call
+	// positions by method expressions, or functions.
+	logf := logger(ctx, "convert function", snapshot.Options().VerboseOutput)
+	{
+		snapshot, release, err := snapshot.WithOverlays(ctx, newContent)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		values, err := conv.valueEdits(ctx, snapshot, pgf.URI)
+		if err != nil {
+			return nil, err
+		}
+		for uri, edits := range values {
+			fh, err := snapshot.ReadFile(ctx, uri)
+			if err != nil {
+				return nil, err
+			}
+			src, err := fh.Content()
+			if err != nil {
+				return nil, err
+			}
+			if newContent[uri], err = diff.ApplyBytes(src, edits); err != nil {
+				return nil, bug.Errorf("invalid edits to %s: %v", uri, err)
+			}
+		}
+	}
+
+	// Step 3: inline all calls to the wrapper, and delete it.
+	if err := inlineWrapper(ctx, snapshot, pgf.URI, conv.fn.Name(), "convert "+conv.fn.Name(), logf, newContent); err != nil {
+		return nil, err
+	}
+
+	// Finally, simplify the receivers of the calls to the new method,
+	// and format the declaring file.
+	if conv.toMethod() {
+		if err := conv.simplifyReceivers(ctx, snapshot, newContent); err != nil {
+			return nil, err
+		}
+	}
+	src, err := format.Source(newContent[pgf.URI])
+	if err != nil {
+		return nil, bug.Errorf("formatting converted file: %v", err)
+	}
+	newContent[pgf.URI] = src
+
+	return documentChanges(ctx, snapshot, newContent)
+}
+
+// inlineWrapper inlines all calls of the wrapper of the function name,
+// the last declaration of the file uri, in the workspace updated with
+// newContent, and then deletes the wrapper. It records the new content
+// of each file in newContent. Type errors in the updated package are
+// reported as the failure to perform the operation described by what.
+func inlineWrapper(ctx context.Context, snapshot *cache.Snapshot, uri protocol.DocumentURI, name, what string, logf func(string, ...any), newContent map[protocol.DocumentURI][]byte) error {
+	snapshot, release, err := snapshot.WithOverlays(ctx, newContent)
+	if err != nil {
+		return err
+	}
+	defer release()
+	pkg, pgf, err := NarrowestPackageForFile(ctx, snapshot, uri)
+	if err != nil {
+		return err
+	}
+	if errs := pkg.TypeErrors(); len(errs) > 0 {
+		return fmt.Errorf("cannot %s: %v", what, errs[0].Msg)
+	}
+	wrapper, ok := pgf.File.Decls[len(pgf.File.Decls)-1].(*ast.FuncDecl)
+	if !ok || wrapper.Name.Name != name {
+		return bug.Errorf("wrapper of %s not found", name)
+	}
+	callee, err := inline.AnalyzeCallee(logf, pkg.FileSet(), pkg.Types(), pkg.TypesInfo(), wrapper, pgf.Src)
+	if err != nil {
+		return fmt.Errorf("analyzing callee: %v", err)
+	}
+	inlined, err := inlineAllCalls(ctx, snapshot, pkg, pgf, wrapper, callee, nil, &inline.Options{Logf: logf})
+	if err != nil {
+		return err
+	}
+	for uri, src := range inlined {
+		newContent[uri] = src
+	}
+
+	// The wrapper is still the last declaration.
+	src := newContent[uri]
+	file, err := parser.ParseFile(token.NewFileSet(), uri.Path(), src, parser.SkipObjectResolution)
+	if err != nil {
+		return bug.Errorf("parsing %s: %v", uri, err)
+	}
+	decl := file.Decls[len(file.Decls)-1]
+	newContent[uri] = append(bytes.TrimRight(src[:decl.Pos()-file.FileStart], "\n\t "), '\n')
+	return nil
+}
+
+// simplifyReceivers simplifies the calls (&x).F(...) of the new method
+// F in newContent, as produced by the inliner for calls of a method
+// with a pointer receiver, to x.F(...). As &x is valid, x is
+// addressable unless it is a composite literal.
+func (c *funcConversion) simplifyReceivers(ctx context.Context, snapshot *cache.Snapshot, newContent map[protocol.DocumentURI][]byte) error {
+	snapshot, release, err := snapshot.WithOverlays(ctx, newContent)
+	if err != nil {
+		return err
+	}
+	defer release()
+	for uri := range newContent {
+		pkg, pgf, err := NarrowestPackageForFile(ctx, snapshot, uri)
+		if err != nil {
+			return err
+		}
+		var edits []diff.Edit
+		ast.Inspect(pgf.File, func(n ast.Node) bool {
+			sel, ok := n.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != c.fn.Name() {
+				return true
+			}
+			paren, ok := sel.X.(*ast.ParenExpr)
+			if !ok {
+				return true
+			}
+			addr, ok := paren.X.(*ast.UnaryExpr)
+			if !ok || addr.Op != token.AND || is[*ast.CompositeLit](addr.X) {
+				return true
+			}
+			method, ok := pkg.TypesInfo().Selections[sel]
+			if !ok || method.Kind() != types.MethodVal {
+				return true
+			}
+			if _, named := typesinternal.ReceiverNamed(method.Obj().(*types.Func).Signature().Recv()); named == nil || !c.isNamed(named.Obj()) {
+				return true
+			}
+			edits = append(edits,
+				diff.Edit{Start: int(paren.Pos() - pgf.File.FileStart), End: int(addr.X.Pos() - pgf.File.FileStart)},
+				diff.Edit{Start: int(addr.X.End() - pgf.File.FileStart), End: int(paren.End() - pgf.File.FileStart)})
+			return false // avoid overlapping edits
+		})
+		if newContent[uri], err = diff.ApplyBytes(pgf.Src, edits); err != nil {
+			return bug.Errorf("invalid edits to %s: %v", uri, err)
+		}
+	}
+	return nil
+}
+
+// declarations returns the header of the converted declaration, from
+// the func keyword to its parameters, and the source of the wrapper, a
+// function with the signature of the original that delegates to the
+// converted declaration.
+func (c *funcConversion) declarations(pgf *parsego.File) (header, wrapper string, _ error) {
+	text := func(start, end token.Pos) string {
+		text, _ := pgf.PosText(start, end) // can't fail: positions are within decl
+		return string(text)
+	}
+	var (
+		name    = c.fn.Name()
+		params  = c.decl.Type.Params
+		results string
+	)
+	if c.decl.Type.Results != nil {
+		results = " " + text(c.decl.Type.Results.Pos(), c.decl.Type.Results.End())
+	}
+
+	// Name the parameters of the wrapper, which delegates to the converted
+	// declaration.
+	var (
+		used   = make(map[string]bool)
+		fields []string // the "name type" parameters of the wrapper
+		args   []string // the arguments of the delegated call
+	)
+	for _, list := range []*ast.FieldList{c.decl.Recv, params} {
+		for id := range astutil.FlatFields(list) {
+			if id != nil {
+				used[id.Name] = true
+			}
+		}
+	}
+	var recvName, recvType string
+	if c.decl.Recv != nil {
+		field := c.decl.Recv.List[0]
+		var id *ast.Ident
+		if len(field.Names) > 0 {
+			id = field.Names[0]
+		}
+		recvName = paramName(used, id, "r")
+		recvType = text(field.Type.Pos(), field.Type.End())
+	}
+	for id, field := range astutil.FlatFields(params) {
+		name := paramName(used, id, "p")
+		fields = append(fields, name+" "+text(field.Type.Pos(), field.Type.End()))
+		if is[*ast.Ellipsis](field.Type) {
+			name += "..."
+		}
+		args = append(args, name)
+	}
+
+	var call string
+	if c.toMethod() {
+		// func F(r *T, a int) => func (r *T) F(a int)
+		first := params.List[0]
+		recv := text(first.Type.Pos(), first.Type.End())
+		var rest []string
+		if len(first.Names) > 0 {
+			recv = first.Names[0].Name + " " + recv
+			if len(first.Names) > 1 {
+				var names []string
+				for _, id := range first.Names[1:] {
+					names = append(names, id.Name)
+				}
+				rest = append(rest, strings.Join(names, ", ")+" "+text(first.Type.Pos(), first.Type.End()))
+			}
+		}
+		if len(params.List) > 1 {
+			rest = append(rest, text(params.List[1].Pos(), params.List[len(params.List)-1].End()))
+		}
+		header = fmt.Sprintf("func (%s) %s(%s)", recv, name, strings.Join(rest, ", "))
+		call = fmt.Sprintf("%s.%s(%s)", args[0], name, strings.Join(args[1:], ", "))
+		wrapper = fmt.Sprintf("func %s(%s)%s", name, strings.Join(fields, ", "), results)
+	} else {
+		// func (r *T) F(a int) => func F(r *T, a int)
+		field := c.decl.Recv.List[0]
+		named := len(field.Names) > 0 && field.Names[0].Name != "_"
+		var list []string
+		switch {
+		case params.NumFields() > 0 && len(params.List[0].Names) == 0 && named:
+			// The other parameters must be named too.
+			list = append(list, field.Names[0].Name+" "+recvType)
+			for _, field := range params.List {
+				list = append(list, "_ "+text(field.Type.Pos(), field.Type.End()))
+			}
+		case params.NumFields() > 0 && len(params.List[0].Names) > 0:
+			if !named {
+				recvType = "_ " + recvType
+			} else {
+				recvType = field.Names[0].Name + " " + recvType
+			}
+			list = append(list, recvType, text(params.List[0].Pos(), params.List[len(params.List)-1].End()))
+		default:
+			if named {
+				recvType = field.Names[0].Name + " " + recvType
+			}
+			list = append(list, recvType)
+			if params.NumFields() > 0 {
+				list = append(list, text(params.List[0].Pos(), params.List[len(params.List)-1].End()))
+			}
+		}
+		header = fmt.Sprintf("func %s(%s)", name, strings.Join(list, ", "))
+		call = fmt.Sprintf("%s(%s)", name, strings.Join(append([]string{recvName}, args...), ", "))
+		wrapper = fmt.Sprintf("func (%s %s) %s(%s)%s", recvName, text(field.Type.Pos(), field.Type.End()), name, strings.Join(fields, ", "), results)
+	}
+	if c.decl.Type.Results.NumFields() > 0 {
+		wrapper += " { return " + call + " }"
+	} else {
+		wrapper += " { " + call + " }"
+	}
+	return header, wrapper, nil
+}
+
+// valueEdits returns the edits that replace the references to the
+// wrapper declared in the file declURI of the given snapshot in
+// non-call positions: a function F by the method expression (*T).F,
+// and a method expression (*T).F by the function F, if it has the same
+// type. The other references to a method (method values and method
+// expressions whose receiver type differs) are left for inlineAllCalls.
+func (c *funcConversion) valueEdits(ctx context.Context, snapshot *cache.Snapshot, declURI protocol.DocumentURI) (map[protocol.DocumentURI][]diff.Edit, error) {
+	pkg, pgf, err := NarrowestPackageForFile(ctx, snapshot, declURI)
+	if err != nil {
+		return nil, err
+	}
+	wrapper, ok := pgf.File.Decls[len(pgf.File.Decls)-1].(*ast.FuncDecl)
+	if !ok {
+		return nil, bug.Errorf("wrapper of %s not found", c.fn.Name())
+	}
+	target, ok := pkg.TypesInfo().Defs[wrapper.Name].(*types.Func)
+	if !ok {
+		return nil, bug.Errorf("no object for wrapper of %s", c.fn.Name())
+	}
+	fh, err := snapshot.ReadFile(ctx, declURI)
+	if err != nil {
+		return nil, err
+	}
+	rng, err := pgf.NodeRange(wrapper.Name)
+	if err != nil {
+		return nil, err
+	}
+	refs, err := References(ctx, snapshot, fh, rng, false)
+	if err != nil {
+		return nil, fmt.Errorf("finding references to rewrite: %v", err)
+	}
+
+	var (
+		result = make(map[protocol.DocumentURI][]diff.Edit)
+		pkgs   = make(map[protocol.DocumentURI]*cache.Package)
+	)
+	for _, ref := range refs {
+		refPkg, ok := pkgs[ref.URI]
+		if !ok {
+			refPkg, _, err = NarrowestPackageForFile(ctx, snapshot, ref.URI)
+			if err != nil {
+				return nil, err
+			}
+			pkgs[ref.URI] = refPkg
+		}
+		refPGF, err := refPkg.File(ref.URI)
+		if err != nil {
+			return nil, err
+		}
+		start, end, err := refPGF.RangePos(ref.Range)
+		if err != nil {
+			return nil, err
+		}
+		path, _ := goastutil.PathEnclosingInterval(refPGF.File, start, end)
+		id, ok := path[0].(*ast.Ident)
+		if !ok {
+			return nil, bug.Errorf("corrupted reference %v", ref)
+		}
+		info := refPkg.TypesInfo()
+		if fn, ok := info.Uses[id].(*types.Func); !ok || !sameFunc(fn, target) {
+			continue // e.g. an interface method
+		}
+		var value ast.Expr = id // F, p.F, or T.F
+		if sel, ok := path[1].(*ast.SelectorExpr); ok && sel.Sel == id {
+			value = sel
+		}
+		if i := slices.Index(path, ast.Node(value)); i+1 < len(path) {
+			if call, ok := path[i+1].(*ast.CallExpr); ok && call.Fun == value {
+				continue // a call, to be inlined
+			}
+		}
+
+		var text string
+		if c.toMethod() {
+			// F or p.F => (*T).F or (*p.T).F
+			qual := ""
+			if sel, ok := value.(*ast.SelectorExpr); ok {
+				qual = types.ExprString(sel.X) + "."
+			} else if _, obj := info.Scopes[refPGF.File].Innermost(id.Pos()).LookupParent(c.named.Obj().Name(), id.Pos()); !c.isNamed(obj) {
+				return nil, fmt.Errorf("cannot convert %s to a method expression: %s is shadowed at %s", types.ExprString(value), c.named.Obj().Name(), refPGF.URI.Base())
+			}
+			recv := c.fn.Signature().Params().At(0).Type()
+			if is[*types.Pointer](recv) {
+				text = fmt.Sprintf("(*%s%s).%s", qual, c.named.Obj().Name(), c.fn.Name())
+			} else {
+				text = fmt.Sprintf("%s%s.%s", qual, c.named.Obj().Name(), c.fn.Name())
+			}
+		} else {
+			// (*T).F or (*p.T).F => F or p.F, if of the same type
+			sel, ok := value.(*ast.SelectorExpr)
+			if !ok {
+				continue
+			}
+			seln := info.Selections[sel]
+			if seln == nil || seln.Kind() != types.MethodExpr {
+				continue // a method value, to be eta-abstracted
+			}
+			recv := seln.Type().(*types.Signature).Params().At(0).Type()
+			ptr, ok := recv.(*types.Pointer)
+			if ok {
+				recv = ptr.Elem()
+			}
+			if ok != is[*types.Pointer](c.fn.Signature().Recv().Type()) || !c.isNamed(typesinternal.TypeNameFor(recv)) {
+				continue // a receiver of another type, to be eta-abstracted
+			}
+			// Find the qualifier of the receiver type, if any.
+			qual := ""
+			ast.Inspect(sel.X, func(n ast.Node) bool {
+				if sel, ok := n.(*ast.SelectorExpr); ok {
+					qual = types.ExprString(sel.X) + "."
+				}
+				return qual == ""
+			})
+			text = qual + c.fn.Name()
+		}
+		offStart, offEnd, err := safetoken.Offsets(refPGF.Tok, value.Pos(), value.End())
+		if err != nil {
+			return nil, err
+		}
+		result[ref.URI] = append(result[ref.URI], diff.Edit{Start: offStart, End: offEnd, New: text})
+	}
+	return result, nil
+}
+
+// isNamed reports whether obj is the type name of c.named, possibly
+// from another type-checking of its package.
+func (c *funcConversion) isNamed(obj types.Object) bool {
+	tname, ok := obj.(*types.TypeName)
+	return ok && tname != nil && tname.Pkg() != nil &&
+		tname.Pkg().Path() == c.named.Obj().Pkg().Path() &&
+		tname.Name() == c.named.Obj().Name() &&
+		typesinternal.IsPackageLevel(tname)
+}
+
+// checkMethodUnneeded reports an error if the method, declared in
+// declURI, is needed for its receiver type to implement an interface,
+// so that its signature can't change.
+//
+// (Dynamic uses of the method, through type assertions or reflection,
+// can't be detected.)
+func checkMethodUnneeded(ctx context.Context, snapshot *cache.Snapshot, declURI protocol.DocumentURI, method *types.Func) error {
+	pkgs, err := typeCheckReverseDependencies(ctx, snapshot, declURI, true)
+	if err != nil {
+		return err
+	}
+	var msets typeutil.MethodSetCache
+	for _, p := range pkgs {
+		var f satisfy.Finder
+		f.Find(p.TypesInfo(), p.Syntax())
+		for c := range f.Result {
+			if !types.IsInterface(c.LHS) || types.IsInterface(c.RHS) {
+				continue
+			}
+			lsel := msets.MethodSet(c.LHS).Lookup(method.Pkg(), method.Name())
+			rsel := msets.MethodSet(c.RHS).Lookup(method.Pkg(), method.Name())
+			if lsel == nil || rsel == nil {
+				continue
+			}
+			if m, ok := rsel.Obj().(*types.Func); ok && sameFunc(m, method) {
+				return fmt.Errorf("it is needed for %s to implement %s",
+					types.TypeString(c.RHS, types.RelativeTo(p.Types())), types.TypeString(c.LHS, types.RelativeTo(p.Types())))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"context"
+	"go/types"
+	"path/filepath"
+	"slices"
+
+	"golang.org/x/tools/go/types/objectpath"
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/cache/metadata"
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/internal/event"
+)
+
+// A DanglingReference is a reference, from another package, to a
+// declaration in a file that is about to be deleted.
+type DanglingReference struct {
+	Name     string            // qualified name of the declaration, e.g. "pkg.T.M"
+	Location protocol.Location // location of the reference
+}
+
+// DeletedFileReferences returns the references from other workspace
+// packages to the exported declarations (including methods and fields)
+// of the specified Go files, as reported by a workspace/willDeleteFiles
+// request. These references will no longer compile once the files are
+// deleted. References from within the deleted files themselves are
+// ignored, as are files that belong to no package.
+//
+// The references are found using the cross-reference index of each
+// reverse dependency, so the importing packages need not be
+// type-checked.
+func DeletedFileReferences(ctx context.Context, snapshot *cache.Snapshot, uris []protocol.DocumentURI) ([]DanglingReference, error) {
+	ctx, done := event.Start(ctx, "golang.DeletedFileReferences")
+	defer done()
+
+	workspace, err := snapshot.WorkspaceMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	workspaceIDs := make(map[PackageID]bool, len(workspace))
+	for _, mp := range workspace {
+		workspaceIDs[mp.ID] = true
+	}
+
+	var refs []DanglingReference
+	for _, uri := range uris {
+		if filepath.Ext(uri.Path()) != ".go" {
+			continue // e.g. a directory
+		}
+		pkg, pgf, err := NarrowestPackageForFile(ctx, snapshot, uri)
+		if err != nil {
+			continue // not part of a package
+		}
+
+		// Gather the exported objects declared in the file,
+		// and their names, keyed by object path.
+		names := make(map[objectpath.Path]string)
+		var enc objectpath.Encoder
+		for id, obj := range pkg.TypesInfo().Defs {
+			if obj == nil || !obj.Exported() || id.Pos() < pgf.File.FileStart || id.Pos() > pgf.File.FileEnd {
+				continue
+			}
+			if _, ok := obj.(*types.PkgName); ok {
+				continue
+			}
+			path, err := enc.For(obj)
+			if err != nil {
+				continue // not addressable from another package
+			}
+			sym, _, _ := monikerSymbol(obj)
+			names[path] = pkg.Types().Name() + "." + sym
+		}
+		if len(names) == 0 {
+			continue
+		}
+
+		rdeps, err := snapshot.ReverseDependencies(ctx, pkg.Metadata().ID, true)
+		if err != nil {
+			return nil, err
+		}
+		var ids []PackageID
+		for id := range rdeps {
+			if workspaceIDs[id] {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		indexes, err := snapshot.References(ctx, ids...)
+		if err != nil {
+			return nil, err
+		}
+
+		pkgPath := metadata.PackagePath(pkg.Types().Path())
+		seen := make(map[protocol.Location]bool) // dedup across package variants
+		for path, name := range names {
+			targets := map[PackagePath]map[objectpath.Path]struct{}{
+				pkgPath: {path: {}},
+			}
+			for _, index := range indexes {
+				for _, loc := range index.Lookup(targets) {
+					if !seen[loc] && !slices.Contains(uris, loc.URI) {
+						seen[loc] = true
+						refs = append(refs, DanglingReference{Name: name, Location: loc})
+					}
+				}
+			}
+		}
+	}
+	slices.SortFunc(refs, func(x, y DanglingReference) int {
+		return protocol.CompareLocation(x.Location, y.Location)
+	})
+	return refs, nil
+}
@@ -0,0 +1,667 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package golang
+
+// This file defines the "Extract interface" code action, which declares
+// an interface with methods of a named type, and optionally changes the
+// parameters of a function to accept the interface instead of the type.
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/module"
+	goastutil "golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ast/edge"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/cache/metadata"
+	"golang.org/x/tools/gopls/internal/cache/parsego"
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/util/bug"
+	"golang.org/x/tools/gopls/internal/util/cursorutil"
+	"golang.org/x/tools/gopls/internal/util/safetoken"
+	internalastutil "golang.org/x/tools/internal/astutil"
+	"golang.org/x/tools/internal/packagepath"
+	"golang.org/x/tools/internal/refactor"
+)
+
+// An InterfaceExtraction describes the interface declared by
+// [ExtractInterface].
+type InterfaceExtraction struct {
+	Name     string   // the name of the interface
+	Methods  []string // the names of its methods, which must be exported methods of the type
+	Package  string   // the path of the package in which to declare the interface
+	Function string   // if nonempty, a function whose parameters of the type should accept the interface
+}
+
+// A formFieldError is an error in the answer to a form field.
+type formFieldError struct {
+	id  string // the ID of the field
+	err error
+}
+
+func (e *formFieldError) Error() string { return e.err.Error() }
+func (e *formFieldError) Unwrap() error { return e.err }
+
+// ExtractInterfaceAnswer returns the interface described by the answers
+// to the form of an "extract interface" command.
+//
+// It validates only the syntax of the answers; [ExtractInterface]
+// reports any other problems. Errors specific to one field are of type
+// *formFieldError.
+func ExtractInterfaceAnswer(params *protocol.InteractiveParams) (InterfaceExtraction, error) {
+	var x InterfaceExtraction
+	answer := func(id string) (string, error) {
+		v, err := FormAnswer[string](params, id)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(v), nil
+	}
+
+	name, err := answer("name")
+	if err != nil {
+		return x, err
+	}
+	if !token.IsIdentifier(name) {
+		return x, &formFieldError{"name", fmt.Errorf("invalid interface name: %q", name)}
+	}
+	x.Name = name
+
+	methods, err := answer("methods")
+	if err != nil {
+		return x, err
+	}
+	for m := range strings.SplitSeq(methods, ",") {
+		m = strings.TrimSpace(m)
+		if m == "" {
+			continue
+		}
+		if !token.IsIdentifier(m) || !token.IsExported(m) {
+			return x, &formFieldError{"methods", fmt.Errorf("invalid method name: %q", m)}
+		}
+		if !slices.Contains(x.Methods, m) {
+			x.Methods = append(x.Methods, m)
+		}
+	}
+	if len(x.Methods) == 0 {
+		return x, &formFieldError{"methods", fmt.Errorf("no methods selected")}
+	}
+
+	pkgPath, err := answer("package")
+	if err != nil {
+		return x, err
+	}
+	if err := module.CheckImportPath(pkgPath); err != nil {
+		return x, &formFieldError{"package", fmt.Errorf("invalid package path: %w", err)}
+	}
+	x.Package = pkgPath
+
+	// The function is optional.
+	if slices.ContainsFunc(params.FormAnswers, func(ans protocol.FormAnswer) bool { return ans.ID == "function" }) {
+		fn, err := answer("function")
+		if err != nil {
+			return x, err
+		}
+		if fn != "" {
+			_, name := splitFuncName(fn)
+			if !isFuncName(name) {
+				return x, &formFieldError{"function", fmt.Errorf(`invalid function: want "F", "T.M", or "path/to/pkg.F", got %q`, fn)}
+			}
+		}
+		x.Function = fn
+	}
+	return x, nil
+}
+
+// ExtractInterface returns the edits that declare an interface
+// containing the selected methods of the package-level named type at
+// loc, and, if x.Function is set, change the parameters of that
+// function whose type is the named type, or a pointer to it, to the
+// interface.
+func ExtractInterface(ctx context.Context, snapshot *cache.Snapshot, loc protocol.Location, x InterfaceExtraction) ([]protocol.DocumentChange, error) {
+	pkg, pgf, err := NarrowestPackageForFile(ctx, snapshot, loc.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the named type.
+	var named *types.Named
+	{
+		start, end, err := pgf.RangePos(loc.Range)
+		if err != nil {
+			return nil, err
+		}
+		cur, _, _, _ := internalastutil.Select(pgf.Cursor(), start, end) // can't fail: pgf contains pos
+		named, err = extractInterfaceType(pkg, pgf, cur)
+		if err != nil {
+			return nil, err
+		}
+	}
+	typeName := named.Obj().Name()
+
+	// Find the selected methods.
+	var methods []*types.Func
+	{
+		all := exportedMethods(named)
+		for _, name := range x.Methods {
+			i := slices.IndexFunc(all, func(m *types.Func) bool { return m.Name() == name })
+			if i < 0 {
+				return nil, fmt.Errorf("%s has no exported method %s", typeName, name)
+			}
+			methods = append(methods, all[i])
+		}
+	}
+
+	// Find the destination package.
+	destPkg := pkg
+	if x.Package != pkg.Types().Path() {
+		destPkg, err = typeCheckPackagePath(ctx, snapshot, x.Package)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if obj := destPkg.Types().Scope().Lookup(x.Name); obj != nil {
+		return nil, fmt.Errorf("%s is already declared in package %s", x.Name, destPkg.Types().Name())
+	}
+
+	// Reject methods whose signatures cannot be written in the
+	// destination package, or would create an import cycle.
+	if destPkg != pkg {
+		dependingOnDest := snapshot.MetadataGraph().ReverseReflexiveTransitiveClosure(destPkg.Metadata().ID)
+		for _, m := range methods {
+			if obj := unexportedTypeName(m.Signature(), destPkg.Types().Path()); obj != nil {
+				return nil, fmt.Errorf("method %s refers to %s, which is not exported by package %s", m.Name(), obj.Name(), obj.Pkg().Name())
+			}
+			var err error
+			_ = types.TypeString(m.Signature(), func(p *types.Package) string {
+				if p.Path() == destPkg.Types().Path() || err != nil {
+					return ""
+				}
+				mps := snapshot.MetadataGraph().ForPackagePath[metadata.PackagePath(p.Path())]
+				if len(mps) > 0 {
+					if _, ok := dependingOnDest[mps[0].ID]; ok {
+						err = fmt.Errorf("declaring method %s in package %s would create an import cycle", m.Name(), destPkg.Types().Name())
+						return ""
+					}
+				}
+				if !packagepath.CanImport(destPkg.Types().Path(), p.Path()) {
+					err = fmt.Errorf("declaring method %s in package %s would require import of inaccessible package %s", m.Name(), destPkg.Types().Name(), p.Name())
+				}
+				return ""
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Declare the interface after the type, if it is in the same
+	// package, or after the last declaration of the first file of the
+	// destination package, keeping any comment on the last line of
+	// that declaration with it.
+	after := types.Object(named.Obj())
+	if destPkg != pkg {
+		after, err = lastPackageLevelObject(destPkg)
+		if err != nil {
+			return nil, err
+		}
+	}
+	docs := methodDocs(pkg, methods)
+	emit := func(out *bytes.Buffer, qual types.Qualifier) error {
+		ref := typeName
+		if destPkg != pkg {
+			ref = pkg.Types().Path() + "." + typeName
+		}
+		fmt.Fprintf(out, "// %s is implemented by [%s].\n", x.Name, ref)
+		fmt.Fprintf(out, "type %s interface {\n", x.Name)
+		for i, m := range methods {
+			if doc := docs[m]; doc != nil {
+				if i > 0 {
+					out.WriteString("\n")
+				}
+				for _, c := range doc.List {
+					fmt.Fprintf(out, "\t%s\n", c.Text)
+				}
+			}
+			fmt.Fprintf(out, "\t%s", m.Name())
+			types.WriteSignature(out, m.Signature(), qual)
+			out.WriteString("\n")
+		}
+		out.WriteString("}\n")
+		return nil
+	}
+	fixFset, suggestion, err := spliceDecls(ctx, snapshot, destPkg.Metadata(), destPkg.FileSet(), after, true, emit)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := suggestedFixToDocumentChange(ctx, snapshot, fixFset, suggestion)
+	if err != nil {
+		return nil, err
+	}
+
+	if x.Function != "" {
+		uri, edits, err := acceptInterface(ctx, snapshot, pkg, named, methods, destPkg, x)
+		if err != nil {
+			return nil, err
+		}
+		// Combine the edits of a file that also declares the interface.
+		i := slices.IndexFunc(changes, func(c protocol.DocumentChange) bool {
+			return c.TextDocumentEdit != nil && c.TextDocumentEdit.TextDocument.URI == uri
+		})
+		if i >= 0 {
+			changes[i].TextDocumentEdit.Edits = append(changes[i].TextDocumentEdit.Edits, protocol.AsAnnotatedTextEdits(edits)...)
+		} else {
+			fh, err := snapshot.ReadFile(ctx, uri)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, protocol.DocumentChangeEdit(fh, edits))
+		}
+	}
+	return changes, nil
+}
+
+// extractInterfaceType returns the package-level named type whose
+// declaration encloses cur, if it is a type from which an interface
+// can be extracted.
+func extractInterfaceType(pkg *cache.Package, pgf *parsego.File, cur inspector.Cursor) (*types.Named, error) {
+	spec, curSpec := cursorutil.FirstEnclosing[*ast.TypeSpec](cur)
+	if spec == nil {
+		return nil, fmt.Errorf("no enclosing type declaration")
+	}
+	if curSpec.Parent().Parent().Node() != pgf.File {
+		return nil, fmt.Errorf("enclosing type %s is not at package level", spec.Name.Name)
+	}
+	named, ok := types.Unalias(pkg.TypesInfo().TypeOf(spec.Name)).(*types.Named)
+	if !ok || named.Obj().Name() != spec.Name.Name {
+		return nil, fmt.Errorf("%s is not a defined type", spec.Name.Name)
+	}
+	if types.IsInterface(named) {
+		return nil, fmt.Errorf("%s is already an interface", spec.Name.Name)
+	}
+	if named.TypeParams().Len() > 0 {
+		return nil, fmt.Errorf("cannot extract an interface from generic type %s", spec.Name.Name)
+	}
+	if len(exportedMethods(named)) == 0 {
+		return nil, fmt.Errorf("%s has no exported methods", spec.Name.Name)
+	}
+	return named, nil
+}
+
+// exportedMethods returns the exported methods of the named type and
+// its pointer type: first its declared methods, in order, and then its
+// promoted methods.
+func exportedMethods(named *types.Named) []*types.Func {
+	var methods []*types.Func
+	for m := range named.Methods() {
+		if m.Exported() {
+			methods = append(methods, m)
+		}
+	}
+	mset := types.NewMethodSet(types.NewPointer(named))
+	for sel := range mset.Methods() {
+		m := sel.Obj().(*types.Func)
+		if m.Exported() && len(sel.Index()) > 1 && !slices.ContainsFunc(methods, func(m2 *types.Func) bool { return m2.Name() == m.Name() }) {
+			methods = append(methods, m)
+		}
+	}
+	return methods
+}
+
+// methodDocs returns the doc comments of those methods that are
+// declared in the package.
+func methodDocs(pkg *cache.Package, methods []*types.Func) map[*types.Func]*ast.CommentGroup {
+	docs := make(map[*types.Func]*ast.CommentGroup)
+	for _, m := range methods {
+		if m.Pkg() != pkg.Types() {
+			continue // promoted from another package
+		}
+		if pgf, err := pkg.FileEnclosing(m.Pos()); err == nil {
+			if decl := funcDeclAt(pgf.File, m.Pos()); decl != nil && decl.Doc != nil {
+				docs[m] = decl.Doc
+			}
+		}
+	}
+	return docs
+}
+
+// funcDeclAt returns the declaration of the function or method
+// whose name is at pos, or nil if there is none.
+func funcDeclAt(file *ast.File, pos token.Pos) *ast.FuncDecl {
+	for _, decl := range file.Decls {
+		if decl, ok := decl.(*ast.FuncDecl); ok && decl.Name.Pos() == pos {
+			return decl
+		}
+	}
+	return nil
+}
+
+// unexportedTypeName returns the first unexported package-level type
+// referenced by t that is not declared in the package of the given
+// path, or nil if there is none.
+func unexportedTypeName(t types.Type, path string) *types.TypeName {
+	seen := make(map[types.Type]bool)
+	var find func(t types.Type) *types.TypeName
+	find = func(t types.Type) *types.TypeName {
+		if seen[t] {
+			return nil
+		}
+		seen[t] = true
+		switch t := t.(type) {
+		case *types.Alias:
+			if obj := t.Obj(); obj.Pkg() != nil && obj.Pkg().Path() != path && !obj.Exported() {
+				return obj
+			}
+			return find(types.Unalias(t))
+		case *types.Named:
+			if obj := t.Obj(); obj.Pkg() != nil && obj.Pkg().Path() != path && !obj.Exported() {
+				return obj
+			}
+			for arg := range t.TypeArgs().Types() {
+				if obj := find(arg); obj != nil {
+					return obj
+				}
+			}
+		case interface{ Elem() types.Type }: // pointer, slice, array, chan, map value
+			if m, ok := t.(*types.Map); ok {
+				if obj := find(m.Key()); obj != nil {
+					return obj
+				}
+			}
+			return find(t.Elem())
+		case *types.Signature:
+			for _, tuple := range []*types.Tuple{t.Params(), t.Results()} {
+				for v := range tuple.Variables() {
+					if obj := find(v.Type()); obj != nil {
+						return obj
+					}
+				}
+			}
+		case *types.Struct:
+			for f := range t.Fields() {
+				if obj := find(f.Type()); obj != nil {
+					return obj
+				}
+			}
+		case *types.Interface:
+			for m := range t.Methods() {
+				if obj := find(m.Type()); obj != nil {
+					return obj
+				}
+			}
+		}
+		return nil
+	}
+	return find(t)
+}
+
+// typeCheckPackagePath returns the type-checked package of the
+// workspace with the given path.
+func typeCheckPackagePath(ctx context.Context, snapshot *cache.Snapshot, path string) (*cache.Package, error) {
+	mps := snapshot.MetadataGraph().ForPackagePath[metadata.PackagePath(path)]
+	if len(mps) == 0 || !snapshot.IsWorkspacePackage(mps[0].ID) {
+		return nil, fmt.Errorf("package %q is not in the workspace", path)
+	}
+	pkgs, err := snapshot.TypeCheck(ctx, mps[0].ID)
+	if err != nil {
+		return nil, err
+	}
+	return pkgs[0], nil
+}
+
+// lastPackageLevelObject returns the package-level object of pkg that
+// is declared last in its first file.
+func lastPackageLevelObject(pkg *cache.Package) (types.Object, error) {
+	files := pkg.CompiledGoFiles()
+	if len(files) == 0 {
+		return nil, fmt.Errorf("package %s has no files", pkg.Types().Name())
+	}
+	first := files[0]
+	var last types.Object
+	scope := pkg.Types().Scope()
+	for _, name := range scope.Names() {
+		obj := scope.Lookup(name)
+		if first.File.FileStart <= obj.Pos() && obj.Pos() <= first.File.FileEnd &&
+			(last == nil || obj.Pos() > last.Pos()) {
+			last = obj
+		}
+	}
+	if last == nil {
+		return nil, fmt.Errorf("file %s declares nothing after which to declare the interface", first.URI.Base())
+	}
+	return last, nil
+}
+
+// splitFuncName splits a function name of the form accepted by
+// [InterfaceExtraction.Function] into its package path, if any, and
+// its name, "F" or "T.M".
+func splitFuncName(s string) (path, name string) {
+	slash := strings.LastIndex(s, "/")
+	if slash < 0 {
+		return "", s
+	}
+	if dot := strings.Index(s[slash:], "."); dot >= 0 {
+		return s[:slash+dot], s[slash+dot+1:]
+	}
+	return s, ""
+}
+
+// isFuncName reports whether name has the form "F" or "T.M".
+func isFuncName(name string) bool {
+	parts := strings.Split(name, ".")
+	return len(parts) <= 2 && !slices.ContainsFunc(parts, func(s string) bool { return !token.IsIdentifier(s) })
+}
+
+// lookupFunc returns the function or concrete method denoted by s, a
+// function name of the form accepted by [InterfaceExtraction.Function],
+// along with its package. Unqualified names, and names whose first
+// segment is not declared by pkg, are resolved in pkg.
+func lookupFunc(ctx context.Context, snapshot *cache.Snapshot, pkg *cache.Package, s string) (*types.Func, *cache.Package, error) {
+	path, name := splitFuncName(s)
+	if path == "" {
+		// "fmt.Println" is qualified, "T.M" is not.
+		if first, rest, ok := strings.Cut(name, "."); ok && pkg.Types().Scope().Lookup(first) == nil {
+			path, name = first, rest
+		}
+	}
+	fnPkg := pkg
+	if path != "" && path != pkg.Types().Path() {
+		var err error
+		fnPkg, err = typeCheckPackagePath(ctx, snapshot, path)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var obj types.Object
+	if typ, method, ok := strings.Cut(name, "."); ok {
+		if tname, ok := fnPkg.Types().Scope().Lookup(typ).(*types.TypeName); ok && !types.IsInterface(tname.Type()) {
+			obj, _, _ = types.LookupFieldOrMethod(tname.Type(), true, fnPkg.Types(), method)
+		}
+	} else {
+		obj = fnPkg.Types().Scope().Lookup(name)
+	}
+	fn, ok := obj.(*types.Func)
+	if !ok || fn.Pkg() != fnPkg.Types() {
+		return nil, nil, fmt.Errorf("no function or method %s in package %s", name, fnPkg.Types().Name())
+	}
+	return fn, fnPkg, nil
+}
+
+// acceptInterface returns the edits to the file that declares the
+// function x.Function that change its parameters of the named type (or
+// a pointer to it) to the interface x.Name, declared in destPkg.
+//
+// The function may use such a parameter only to refer to the methods
+// of the interface, and the types of its arguments must have all
+// these methods, so that the change preserves the validity of the
+// function and its callers.
+func acceptInterface(ctx context.Context, snapshot *cache.Snapshot, pkg *cache.Package, named *types.Named, methods []*types.Func, destPkg *cache.Package, x InterfaceExtraction) (protocol.DocumentURI, []protocol.TextEdit, error) {
+	fn, fnPkg, err := lookupFunc(ctx, snapshot, pkg, x.Function)
+	if err != nil {
+		return "", nil, err
+	}
+	pgf, err := fnPkg.FileEnclosing(fn.Pos())
+	if err != nil {
+		return "", nil, err
+	}
+	decl := funcDeclAt(pgf.File, fn.Pos())
+	if decl == nil {
+		return "", nil, fmt.Errorf("no declaration of %s", fn.Name())
+	}
+	info := fnPkg.TypesInfo()
+
+	// The function's package must be able to refer to the interface.
+	if fnPkg != destPkg {
+		if !token.IsExported(x.Name) {
+			return "", nil, fmt.Errorf("%s cannot refer to unexported interface %s of package %s", fn.Name(), x.Name, destPkg.Types().Name())
+		}
+		if !packagepath.CanImport(fnPkg.Types().Path(), destPkg.Types().Path()) {
+			return "", nil, fmt.Errorf("package %s cannot import package %s", fnPkg.Types().Name(), destPkg.Types().Name())
+		}
+		if _, ok := snapshot.MetadataGraph().ReverseReflexiveTransitiveClosure(fnPkg.Metadata().ID)[destPkg.Metadata().ID]; ok {
+			return "", nil, fmt.Errorf("changing %s to accept %s would create an import cycle", fn.Name(), x.Name)
+		}
+	}
+
+	// Find the parameters of the named type, and check their uses.
+	isMethod := func(name string) bool {
+		return slices.ContainsFunc(methods, func(m *types.Func) bool { return m.Name() == name })
+	}
+	var fields []*ast.Field
+	for _, field := range decl.Type.Params.List {
+		t := info.TypeOf(field.Type)
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		// The function's package may have been type-checked separately,
+		// so compare the types by name.
+		if n, ok := types.Unalias(t).(*types.Named); !ok || n.Obj().Pkg() == nil ||
+			n.Obj().Name() != named.Obj().Name() || n.Obj().Pkg().Path() != named.Obj().Pkg().Path() {
+			continue
+		}
+		t = info.TypeOf(field.Type)
+		mset := types.NewMethodSet(t)
+		for _, m := range methods {
+			if mset.Lookup(m.Pkg(), m.Name()) == nil {
+				return "", nil, fmt.Errorf("cannot change type of parameter of %s: type %s lacks method %s (it has a pointer receiver)", fn.Name(), types.TypeString(t, types.RelativeTo(fnPkg.Types())), m.Name())
+			}
+		}
+		for _, id := range field.Names {
+			v := info.Defs[id]
+			if v == nil || decl.Body == nil {
+				continue
+			}
+			curBody, _ := pgf.Cursor().FindNode(decl.Body)
+			for curId := range curBody.Preorder((*ast.Ident)(nil)) {
+				if info.Uses[curId.Node().(*ast.Ident)] != v {
+					continue
+				}
+				if curId.ParentEdgeKind() == edge.SelectorExpr_X {
+					sel := curId.Parent().Node().(*ast.SelectorExpr)
+					if seln, ok := info.Selections[sel]; ok && seln.Kind() == types.MethodVal && isMethod(sel.Sel.Name) {
+						continue
+					}
+				}
+				return "", nil, fmt.Errorf("cannot change type of parameter %s of %s: it is used at line %d other than to call a method of %s", id.Name, fn.Name(), safetoken.Line(pgf.Tok, curId.Node().Pos()), x.Name)
+			}
+		}
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		return "", nil, fmt.Errorf("%s has no parameters of type %s", fn.Name(), named.Obj().Name())
+	}
+	if err := checkOnlyCalled(ctx, snapshot, fn, pgf, decl); err != nil {
+		return "", nil, err
+	}
+
+	// Replace the types of the parameters.
+	var edits []protocol.TextEdit
+	ifaceName := x.Name
+	if fnPkg != destPkg {
+		prefix, importEdits := refactor.AddImport(info, pgf.File, destPkg.Types().Name(), destPkg.Types().Path(), x.Name, fields[0].Type.Pos())
+		ifaceName = prefix + x.Name
+		for _, edit := range importEdits {
+			rng, err := pgf.PosRange(edit.Pos, edit.End)
+			if err != nil {
+				return "", nil, err
+			}
+			edits = append(edits, protocol.TextEdit{Range: rng, NewText: string(edit.NewText)})
+		}
+	}
+	for _, field := range fields {
+		rng, err := pgf.NodeRange(field.Type)
+		if err != nil {
+			return "", nil, err
+		}
+		edits = append(edits, protocol.TextEdit{Range: rng, NewText: ifaceName})
+	}
+	return pgf.URI, edits, nil
+}
+
+// checkOnlyCalled returns an error if the function fn, declared by
+// decl in pgf, is referenced other than by a call, since changing its
+// parameter types would change the type of such a function value.
+func checkOnlyCalled(ctx context.Context, snapshot *cache.Snapshot, fn *types.Func, pgf *parsego.File, decl *ast.FuncDecl) error {
+	fh, err := snapshot.ReadFile(ctx, pgf.URI)
+	if err != nil {
+		return err
+	}
+	rng, err := pgf.NodeRange(decl.Name)
+	if err != nil {
+		return err
+	}
+	refs, err := References(ctx, snapshot, fh, rng, false)
+	if err != nil {
+		return fmt.Errorf("finding references to %s: %v", fn.Name(), err)
+	}
+	pkgs := make(map[protocol.DocumentURI]*cache.Package)
+	for _, ref := range refs {
+		refPkg, ok := pkgs[ref.URI]
+		if !ok {
+			refPkg, _, err = NarrowestPackageForFile(ctx, snapshot, ref.URI)
+			if err != nil {
+				return err
+			}
+			pkgs[ref.URI] = refPkg
+		}
+		refPGF, err := refPkg.File(ref.URI)
+		if err != nil {
+			return err
+		}
+		start, end, err := refPGF.RangePos(ref.Range)
+		if err != nil {
+			return err
+		}
+		path, _ := goastutil.PathEnclosingInterval(refPGF.File, start, end)
+		id, ok := path[0].(*ast.Ident)
+		if !ok {
+			return bug.Errorf("corrupted reference %v", ref)
+		}
+		// A reference to an interface method that fn implements
+		// is not a call of fn, even if it is a call.
+		if used, ok := refPkg.TypesInfo().Uses[id].(*types.Func); ok && sameFunc(used, fn) {
+			var value ast.Expr = id // F, p.F, or x.M
+			if sel, ok := path[1].(*ast.SelectorExpr); ok && sel.Sel == id {
+				value = sel
+			}
+			if i := slices.Index(path, ast.Node(value)); i+1 < len(path) {
+				if call, ok := path[i+1].(*ast.CallExpr); ok && call.Fun == value {
+					continue
+				}
+			}
+		}
+		return fmt.Errorf("cannot change the parameters of %s: it is referenced at %s:%d other than by a call", fn.Name(), ref.URI.Base(), ref.Range.Start.Line+1)
+	}
+	return nil
+}
@@ -57,6 +57,8 @@ const (
 	fixInlineCall              = "inline_call" // keep consistent with go/analysis/passes/inline Diagnostic.Category
 	fixInlineVariable          = "inline_variable"
 	fixInvertIfCondition       = "invert_if_condition"
+	fixIfToSwitch              = "if_to_switch"
+	fixSwitchToIf              = "switch_to_if"
 	fixSplitLines              = "split_lines"
 	fixJoinLines               = "join_lines"
 	fixCreateUndeclared        = "create_undeclared"
@@ -103,6 +105,8 @@ func ApplyFix(ctx context.Context, fix string, snapshot *cache.Snapshot, fh file
 		fixInlineCall:              inlineCall,
 		fixInlineVariable:          singleFile(inlineVariableOne),
 		fixInvertIfCondition:       singleFile(invertIfCondition),
+		fixIfToSwitch:              singleFile(ifToSwitch),
+		fixSwitchToIf:              singleFile(switchToIf),
 		fixSplitLines:              singleFile(splitLines),
 		fixJoinLines:               singleFile(joinLines),
 		fixCreateUndeclared:        singleFile(createUndeclared),
@@ -150,6 +154,10 @@ func ApplyFix(ctx context.Context, fix string, snapshot *cache.Snapshot, fh file
 				bug.Report("no token.File for TextEdit.Pos (#68818)")
 			case fixInvertIfCondition:
 				bug.Report("no token.File for TextEdit.Pos (#68818)")
+			case fixIfToSwitch:
+				bug.Report("no token.File for TextEdit.Pos (#68818)")
+			case fixSwitchToIf:
+				bug.Report("no token.File for TextEdit.Pos (#68818)")
 			case fixSplitLines:
 				bug.Report("no token.File for TextEdit.Pos (#68818)")
 			case fixJoinLines:
@@ -14,15 +14,18 @@ import (
 	"go/format"
 	"go/parser"
 	"go/token"
+	"slices"
 	"strings"
 	"text/scanner"
 
+	"golang.org/x/tools/go/ast/astutil"
 	"golang.org/x/tools/gopls/internal/cache"
 	"golang.org/x/tools/gopls/internal/cache/parsego"
 	"golang.org/x/tools/gopls/internal/file"
 	"golang.org/x/tools/gopls/internal/protocol"
 	"golang.org/x/tools/gopls/internal/util/safetoken"
 	"golang.org/x/tools/gopls/internal/util/tokeninternal"
+	internalastutil "golang.org/x/tools/internal/astutil"
 	"golang.org/x/tools/internal/diff"
 	"golang.org/x/tools/internal/event"
 	"golang.org/x/tools/internal/imports"
@@ -38,16 +41,271 @@ func Format(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle) ([]pr
 	if err != nil {
 		return nil, err
 	}
+	formatted, err := formatFile(ctx, snapshot, fh, pgf)
+	if err != nil {
+		return nil, err
+	}
+	return computeTextEdits(ctx, pgf, formatted)
+}
+
+// FormatRanges formats the complete statements, declarations, and
+// specs that intersect the given ranges of a file, leaving the rest of
+// the file untouched. The formatting of each such node is identical to
+// the result of formatting the entire file.
+func FormatRanges(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle, rngs []protocol.Range) ([]protocol.TextEdit, error) {
+	ctx, done := event.Start(ctx, "golang.FormatRanges")
+	defer done()
+
+	pgf, err := snapshot.ParseGo(ctx, fh, parsego.Full)
+	if err != nil {
+		return nil, err
+	}
+	var posRanges []internalastutil.Range
+	for _, rng := range rngs {
+		start, end, err := pgf.RangePos(rng)
+		if err != nil {
+			return nil, err
+		}
+		posRanges = append(posRanges, internalastutil.RangeOf(start, end))
+	}
+	edits, err := formatRangesEdits(ctx, snapshot, fh, pgf, posRanges)
+	if err != nil {
+		return nil, err
+	}
+	return protocol.EditsFromDiffEdits(pgf.Mapper, edits)
+}
+
+// FormatOnType formats the code completed by typing the character ch,
+// which ends at position pos:
+//
+//   - after "}", the block, composite literal, or other braced
+//     construct it closes, along with its enclosing statement;
+//   - after ";", the statement it terminates, though the semicolon
+//     itself is retained so that the user may continue typing;
+//   - after a newline, the statement on the previous line, but not
+//     the new line containing the cursor, whose indentation the editor
+//     has typically provided.
+//
+// The formatting of each node is identical to the result of
+// formatting the entire file. If the file does not parse, as is often
+// the case while typing, the result is empty.
+func FormatOnType(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle, pos protocol.Position, ch string) ([]protocol.TextEdit, error) {
+	ctx, done := event.Start(ctx, "golang.FormatOnType")
+	defer done()
+
+	pgf, err := snapshot.ParseGo(ctx, fh, parsego.Full)
+	if err != nil {
+		return nil, err
+	}
+	if pgf.ParseErr != nil {
+		return nil, nil // incomplete code
+	}
+	offset, err := pgf.Mapper.PositionOffset(pos)
+	if err != nil {
+		return nil, err
+	}
+	if ch != "\n" && (offset < len(ch) || string(pgf.Src[offset-len(ch):offset]) != ch) {
+		return nil, nil // the typed character is not before pos
+	}
+	p := pgf.Tok.Pos(offset)
+	lineStart := bytes.LastIndexByte(pgf.Src[:offset], '\n') + 1 // offset of start of current line
+
+	var (
+		rng  internalastutil.Range
+		keep func(diff.Edit) bool // reports whether to keep an edit
+	)
+	switch ch {
+	case "}":
+		// Find the braced construct that ends at the brace.
+		path, _ := astutil.PathEnclosingInterval(pgf.File, p-1, p)
+		for _, n := range path {
+			if n.End() == p {
+				rng = internalastutil.NodeRange(n)
+				break
+			}
+		}
+		if !rng.IsValid() {
+			return nil, nil // not a closing brace
+		}
+
+	case ";":
+		rng = internalastutil.RangeOf(pgf.Tok.Pos(lineStart), p)
+		keep = func(edit diff.Edit) bool {
+			return !(edit.Start < offset && offset-1 < edit.End) // preserve the semicolon
+		}
+
+	case "\n":
+		// The editor may have already indented the new line,
+		// so pos is not necessarily just after the newline.
+		if lineStart == 0 {
+			return nil, nil // no previous line
+		}
+		prevLineStart := bytes.LastIndexByte(pgf.Src[:lineStart-1], '\n') + 1
+		rng = internalastutil.RangeOf(pgf.Tok.Pos(prevLineStart), pgf.Tok.Pos(lineStart-1))
+		// Don't touch the new line containing the cursor.
+		curLineEnd := offset
+		if i := bytes.IndexByte(pgf.Src[offset:], '\n'); i >= 0 {
+			curLineEnd += i
+		} else {
+			curLineEnd = len(pgf.Src)
+		}
+		keep = func(edit diff.Edit) bool {
+			return edit.End < lineStart || curLineEnd < edit.Start
+		}
+
+	default:
+		return nil, nil // unsupported trigger character
+	}
+
+	edits, err := formatRangesEdits(ctx, snapshot, fh, pgf, []internalastutil.Range{rng})
+	if err != nil {
+		return nil, err
+	}
+	if keep != nil {
+		edits = slices.DeleteFunc(edits, func(edit diff.Edit) bool { return !keep(edit) })
+	}
+	return protocol.EditsFromDiffEdits(pgf.Mapper, edits)
+}
+
+// formatRangesEdits returns the edits of the whole-file formatting,
+// clipped to the complete statements, declarations, and specs that
+// intersect the given ranges.
+func formatRangesEdits(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle, pgf *parsego.File, rngs []internalastutil.Range) ([]diff.Edit, error) {
+	formatted, err := formatFile(ctx, snapshot, fh, pgf)
+	if err != nil {
+		return nil, err
+	}
 
+	// Compute the line-aligned byte intervals of the nodes to format.
+	type interval struct{ start, end int }
+	var intervals []interval
+	for _, rng := range rngs {
+		start, end := formatRangeBounds(pgf.File, rng.Pos(), rng.End())
+		startOffset, endOffset, err := safetoken.Offsets(pgf.Tok, start, end)
+		if err != nil {
+			return nil, err
+		}
+		// Widen to the start of the first line, and to the end
+		// (excluding the newline) of the last line, so that edits
+		// to indentation and trailing space are included.
+		startOffset = bytes.LastIndexByte(pgf.Src[:startOffset], '\n') + 1
+		if i := bytes.IndexByte(pgf.Src[endOffset:], '\n'); i >= 0 {
+			endOffset += i
+		} else {
+			endOffset = len(pgf.Src)
+		}
+		intervals = append(intervals, interval{startOffset, endOffset})
+	}
+
+	// Keep only the edits of the whole-file formatting
+	// that intersect one of the intervals, clipped to it.
+	var edits []diff.Edit
+	for _, edit := range diff.Strings(string(pgf.Src), formatted) {
+		for _, in := range intervals {
+			if in.start <= edit.Start && edit.End <= in.end {
+				edits = append(edits, edit)
+				break
+			}
+			if edit.Start < in.end && in.start < edit.End {
+				edits = append(edits, clipEdit(pgf.Src, edit, in.start, in.end))
+				break
+			}
+		}
+	}
+	return edits, nil
+}
+
+// clipEdit returns the portion of an edit of src that straddles the
+// boundary of the interval [start, end), so that the text outside the
+// interval is unchanged. Such edits arise, for example, when gofmt
+// deletes both the trailing space of a line and the blank line that
+// follows it. If the replacement text cannot be apportioned, the
+// entire edit is returned, as the range would otherwise be left
+// partially formatted.
+func clipEdit(src []byte, edit diff.Edit, start, end int) diff.Edit {
+	clipped := edit
+	if edit.Start < start {
+		before := string(src[edit.Start:start])
+		if !strings.HasPrefix(clipped.New, before) && clipped.New != "" {
+			return edit
+		}
+		clipped.Start, clipped.New = start, strings.TrimPrefix(clipped.New, before)
+	}
+	if end < edit.End {
+		after := string(src[end:edit.End])
+		if !strings.HasSuffix(clipped.New, after) && clipped.New != "" {
+			return edit
+		}
+		clipped.End, clipped.New = end, strings.TrimSuffix(clipped.New, after)
+	}
+	return clipped
+}
+
+// formatRangeBounds expands the interval [start, end) to the
+// boundaries of the complete statements, declarations, specs, or
+// fields that it intersects.
+func formatRangeBounds(file *ast.File, start, end token.Pos) (token.Pos, token.Pos) {
+	path, _ := astutil.PathEnclosingInterval(file, start, end)
+	for _, n := range path {
+		var (
+			itemStart, itemEnd token.Pos
+			ok                 bool
+		)
+		switch n := n.(type) {
+		case *ast.File:
+			itemStart, itemEnd, ok = intersectingItems(n.Decls, start, end)
+		case *ast.BlockStmt:
+			itemStart, itemEnd, ok = intersectingItems(n.List, start, end)
+		case *ast.CaseClause:
+			itemStart, itemEnd, ok = intersectingItems(n.Body, start, end)
+		case *ast.CommClause:
+			itemStart, itemEnd, ok = intersectingItems(n.Body, start, end)
+		case *ast.GenDecl:
+			itemStart, itemEnd, ok = intersectingItems(n.Specs, start, end)
+		case *ast.FieldList:
+			itemStart, itemEnd, ok = intersectingItems(n.List, start, end)
+		case ast.Stmt, ast.Decl, ast.Spec:
+			return n.Pos(), n.End()
+		default:
+			continue
+		}
+		if ok {
+			return min(start, itemStart), max(end, itemEnd)
+		}
+		// The interval lies between items, for example in
+		// the blank space between two statements.
+		return start, end
+	}
+	return start, end
+}
+
+// intersectingItems returns the extent of the items of a list that
+// intersect the interval [start, end), and reports whether there were any.
+func intersectingItems[N ast.Node](items []N, start, end token.Pos) (token.Pos, token.Pos, bool) {
+	itemStart, itemEnd := token.NoPos, token.NoPos
+	for _, item := range items {
+		if item.Pos() <= end && start <= item.End() {
+			if !itemStart.IsValid() {
+				itemStart = item.Pos()
+			}
+			itemEnd = item.End()
+		}
+	}
+	return itemStart, itemEnd, itemStart.IsValid()
+}
+
+// formatFile returns the formatted content of the file, as
+// determined by gofmt and the user's formatting options.
+func formatFile(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle, pgf *parsego.File) (string, error) {
 	// Even if this file has parse errors, it might still be possible to format it.
 	// Using format.Node on an AST with errors may result in code being modified.
 	// Attempt to format the source of this file instead.
 	if pgf.ParseErr != nil {
 		formatted, err := formatSource(ctx, fh)
 		if err != nil {
-			return nil, err
+			return "", err
 		}
-		return computeTextEdits(ctx, pgf, string(formatted))
+		return string(formatted), nil
 	}
 
 	// format.Node changes slightly from one release to another, so the version
@@ -57,7 +315,7 @@ func Format(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle) ([]pr
 	buf := &bytes.Buffer{}
 	fset := tokeninternal.FileSetFor(pgf.Tok)
 	if err := format.Node(buf, fset, pgf.File); err != nil {
-		return nil, err
+		return "", err
 	}
 	formatted := buf.String()
 
@@ -84,11 +342,11 @@ func Format(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle) ([]pr
 		}
 		b, err := gofumptFormat.Source(buf.Bytes(), opts)
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 		formatted = string(b)
 	}
-	return computeTextEdits(ctx, pgf, formatted)
+	return formatted, nil
 }
 
 func formatSource(ctx context.Context, fh file.Handle) ([]byte, error) {
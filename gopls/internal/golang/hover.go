@@ -143,6 +143,31 @@ func Hover(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle, rng pr
 	}, nil
 }
 
+// HoverDoc returns the signature and the full documentation, in doc
+// comment syntax, of the symbol at the given range, as displayed by
+// Hover but without regard to the hover options. For a type, the
+// signature is its declaration, followed by its promoted fields and
+// methods. It returns "", "", nil if there is no symbol at the range.
+func HoverDoc(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle, rng protocol.Range) (signature, doc string, err error) {
+	ctx, done := event.Start(ctx, "golang.HoverDoc")
+	defer done()
+
+	_, h, err := hover(ctx, snapshot, fh, rng)
+	if err != nil || h == nil {
+		return "", "", err
+	}
+	if h.typeDecl == "" {
+		return h.Signature, h.FullDocumentation, nil
+	}
+	parts := []string{h.typeDecl}
+	for _, s := range []string{h.promotedFields, h.methods} {
+		if s = strings.Trim(s, "\n"); s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, "\n\n"), h.FullDocumentation, nil
+}
+
 // findRhsTypeDecl finds an alias's rhs type and returns its declaration.
 // The rhs of an alias might be an alias as well, but we feel this is a rare case.
 // It returns an empty string if the given obj is not an alias.
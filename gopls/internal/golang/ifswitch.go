@@ -0,0 +1,451 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package golang
+
+// This file defines the conversions of an if/else-if chain to a
+// switch statement, and back.
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/cache/parsego"
+	"golang.org/x/tools/gopls/internal/util/cursorutil"
+	"golang.org/x/tools/internal/typesinternal"
+)
+
+// ifToSwitch is a singleFileFixFunc that converts an if/else-if chain
+// to a switch statement: a tagged switch if all conditions compare the
+// same expression with ==, and a tagless one otherwise.
+func ifToSwitch(pkg *cache.Package, pgf *parsego.File, start, end token.Pos) (*token.FileSet, *analysis.SuggestedFix, error) {
+	chain, err := canIfToSwitch(pgf.Cursor(), start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		head   = chain[0]
+		text   = func(n ast.Node) string { return posText(pgf, n.Pos(), n.End()) }
+		indent = lineIndent(pgf, head.Pos())
+		buf    strings.Builder
+	)
+	tag, values := switchTag(pkg.TypesInfo(), chain)
+
+	buf.WriteString("switch ")
+	var emitted []ast.Node // the nodes whose text is kept
+	if head.Init != nil {
+		fmt.Fprintf(&buf, "%s; ", text(head.Init))
+		emitted = append(emitted, head.Init)
+	}
+	if tag != nil {
+		fmt.Fprintf(&buf, "%s ", text(tag))
+	}
+	buf.WriteString("{\n")
+	for i, stmt := range chain {
+		var (
+			cases       []string
+			caseNodes   []ast.Node
+			stmtStart   = stmt.Pos()
+			stmtEmitted = emitted
+		)
+		if i > 0 {
+			stmtStart = chain[i-1].Body.End()
+			stmtEmitted = nil
+		}
+		if tag != nil {
+			for _, v := range values[i] {
+				cases = append(cases, text(v))
+				caseNodes = append(caseNodes, v)
+			}
+		} else {
+			cases = append(cases, text(stmt.Cond))
+			caseNodes = append(caseNodes, stmt.Cond)
+		}
+		fmt.Fprintf(&buf, "%scase %s:%s%s\n", indent, strings.Join(cases, ", "),
+			strayComments(pgf.File, stmtStart, stmt.Body.Lbrace, append(stmtEmitted, caseNodes...)...),
+			blockText(pgf, stmt.Body, indent))
+	}
+	last := chain[len(chain)-1]
+	if block, ok := last.Else.(*ast.BlockStmt); ok {
+		fmt.Fprintf(&buf, "%sdefault:%s%s\n", indent,
+			strayComments(pgf.File, last.Body.End(), block.Lbrace),
+			blockText(pgf, block, indent))
+	}
+	fmt.Fprintf(&buf, "%s}", indent)
+
+	return pkg.FileSet(), &analysis.SuggestedFix{
+		TextEdits: []analysis.TextEdit{{
+			Pos:     head.Pos(),
+			End:     head.End(),
+			NewText: []byte(buf.String()),
+		}},
+	}, nil
+}
+
+// canIfToSwitch returns the if/else-if chain whose headers (that is,
+// excluding the blocks) enclose the range, or an error if there is none,
+// or it can't be converted to a switch statement.
+func canIfToSwitch(curFile inspector.Cursor, start, end token.Pos) ([]*ast.IfStmt, error) {
+	cur, _ := curFile.FindByPos(start, end)
+	stmt, cur := cursorutil.FirstEnclosing[*ast.IfStmt](cur)
+	if stmt == nil {
+		return nil, fmt.Errorf("not an if statement")
+	}
+	// Find the head of the chain.
+	for {
+		parent, ok := cur.Parent().Node().(*ast.IfStmt)
+		if !ok || parent.Else != cur.Node() {
+			break
+		}
+		stmt, cur = parent, cur.Parent()
+	}
+	var chain []*ast.IfStmt
+	for {
+		if posRangeContains(stmt.Body.Lbrace+1, stmt.Body.Rbrace, start, end) {
+			return nil, fmt.Errorf("selection is within a block")
+		}
+		if stmt.Init != nil && len(chain) > 0 {
+			return nil, fmt.Errorf("else-if statement has an init statement")
+		}
+		if hasBreak(stmt.Body) {
+			return nil, fmt.Errorf("block contains a break statement")
+		}
+		chain = append(chain, stmt)
+		if next, ok := stmt.Else.(*ast.IfStmt); ok {
+			stmt = next
+			continue
+		}
+		if block, ok := stmt.Else.(*ast.BlockStmt); ok {
+			if posRangeContains(block.Lbrace+1, block.Rbrace, start, end) {
+				return nil, fmt.Errorf("selection is within a block")
+			}
+			if hasBreak(block) {
+				return nil, fmt.Errorf("block contains a break statement")
+			}
+		}
+		break
+	}
+	if len(chain) < 2 {
+		return nil, fmt.Errorf("no else-if statement")
+	}
+	return chain, nil
+}
+
+// switchTag returns the tag of a switch statement equivalent to the
+// if/else-if chain, and the values of the cases for each statement of
+// the chain, or nil if the switch must be tagless.
+//
+// All conditions must be disjunctions of comparisons tag == v (or
+// v == tag), where tag is a variable or a selection of a field, and v
+// has no side effects, so that evaluating tag once is equivalent even
+// if v is not a constant. The constant values must not be repeated, as
+// they can't be in a switch statement.
+func switchTag(info *types.Info, chain []*ast.IfStmt) (ast.Expr, [][]ast.Expr) {
+	// comparisons returns the operands of the comparisons of a disjunction.
+	var comparisons func(cond ast.Expr) []*ast.BinaryExpr
+	comparisons = func(cond ast.Expr) []*ast.BinaryExpr {
+		cond = ast.Unparen(cond)
+		if bin, ok := cond.(*ast.BinaryExpr); ok {
+			switch bin.Op {
+			case token.LOR:
+				x, y := comparisons(bin.X), comparisons(bin.Y)
+				if x == nil || y == nil {
+					return nil
+				}
+				return append(x, y...)
+			case token.EQL:
+				return []*ast.BinaryExpr{bin}
+			}
+		}
+		return nil
+	}
+
+	first := comparisons(chain[0].Cond)
+	if first == nil {
+		return nil, nil
+	}
+candidates:
+	for _, tag := range []ast.Expr{first[0].X, first[0].Y} {
+		if !isSimpleTag(tag) {
+			continue
+		}
+		tv, ok := info.Types[tag]
+		if !ok || !tv.Addressable() || !types.Comparable(tv.Type) {
+			continue
+		}
+		var (
+			tagText = types.ExprString(tag)
+			values  [][]ast.Expr
+			consts  []types.TypeAndValue
+		)
+		for _, stmt := range chain {
+			cmps := comparisons(stmt.Cond)
+			if cmps == nil {
+				continue candidates
+			}
+			var vs []ast.Expr
+			for _, cmp := range cmps {
+				var v ast.Expr
+				switch {
+				case types.ExprString(cmp.X) == tagText:
+					v = cmp.Y
+				case types.ExprString(cmp.Y) == tagText:
+					v = cmp.X
+				default:
+					continue candidates
+				}
+				if !typesinternal.NoEffects(info, v) {
+					continue candidates // v might change tag
+				}
+				if tv := info.Types[v]; tv.Value != nil {
+					for _, c := range consts {
+						if types.Identical(types.Default(c.Type), types.Default(tv.Type)) && constant.Compare(c.Value, token.EQL, tv.Value) {
+							continue candidates // a duplicate case
+						}
+					}
+					consts = append(consts, tv)
+				}
+				vs = append(vs, v)
+			}
+			values = append(values, vs)
+		}
+		return tag, values
+	}
+	return nil, nil
+}
+
+// isSimpleTag reports whether e is an identifier, or a selection of one.
+func isSimpleTag(e ast.Expr) bool {
+	switch e := e.(type) {
+	case *ast.Ident:
+		return true
+	case *ast.SelectorExpr:
+		return isSimpleTag(e.X)
+	}
+	return false
+}
+
+// switchToIf is a singleFileFixFunc that converts a switch statement to
+// an if/else-if chain.
+func switchToIf(pkg *cache.Package, pgf *parsego.File, start, end token.Pos) (*token.FileSet, *analysis.SuggestedFix, error) {
+	stmt, err := canSwitchToIf(pgf.Cursor(), start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		text   = func(n ast.Node) string { return posText(pgf, n.Pos(), n.End()) }
+		indent = lineIndent(pgf, stmt.Pos())
+		buf    strings.Builder
+		dflt   *ast.CaseClause
+		first  = true
+	)
+	// The text of a value, parenthesized if needed.
+	operand := func(v ast.Expr, prec int) string {
+		text := text(v)
+		if bin, ok := v.(*ast.BinaryExpr); ok && bin.Op.Precedence() <= prec || hasCompositeLit(v) {
+			text = "(" + text + ")"
+		}
+		return text
+	}
+	// The text of a case body, as a block.
+	body := func(i int) string {
+		clause := stmt.Body.List[i].(*ast.CaseClause)
+		end := stmt.Body.Rbrace
+		if i+1 < len(stmt.Body.List) {
+			end = stmt.Body.List[i+1].Pos()
+		}
+		return fmt.Sprintf("{%s\n%s}", bodyText(posText(pgf, clause.Colon+1, end), indent), indent)
+	}
+	for i, clause := range stmt.Body.List {
+		clause := clause.(*ast.CaseClause)
+		if clause.List == nil {
+			dflt = clause
+			continue
+		}
+		var (
+			conds    []string
+			emitted  []ast.Node
+			comments string // those in the header of the switch or the clause
+		)
+		if first {
+			buf.WriteString("if ")
+			if stmt.Init != nil {
+				fmt.Fprintf(&buf, "%s; ", text(stmt.Init))
+				emitted = append(emitted, stmt.Init)
+			}
+			if stmt.Tag != nil {
+				emitted = append(emitted, stmt.Tag)
+			}
+		} else {
+			buf.WriteString(" else if ")
+		}
+		for _, v := range clause.List {
+			if stmt.Tag != nil {
+				conds = append(conds, fmt.Sprintf("%s == %s", text(stmt.Tag), operand(v, token.EQL.Precedence())))
+			} else {
+				conds = append(conds, operand(v, token.LowestPrec)) // || is the lowest precedence
+			}
+			emitted = append(emitted, v)
+		}
+		buf.WriteString(strings.Join(conds, " || "))
+		buf.WriteString(" ")
+		if first {
+			comments = strayComments(pgf.File, stmt.Pos(), stmt.Body.List[0].Pos(), emitted...)
+		}
+		comments += strayComments(pgf.File, clause.Pos(), clause.Colon, emitted...)
+		b := body(i)
+		buf.WriteString(b[:1] + comments + b[1:])
+		first = false
+	}
+	if dflt != nil {
+		for i, clause := range stmt.Body.List {
+			if clause == dflt {
+				buf.WriteString(" else ")
+				b := body(i)
+				buf.WriteString(b[:1] + strayComments(pgf.File, dflt.Pos(), dflt.Colon) + b[1:])
+			}
+		}
+	}
+
+	return pkg.FileSet(), &analysis.SuggestedFix{
+		TextEdits: []analysis.TextEdit{{
+			Pos:     stmt.Pos(),
+			End:     stmt.End(),
+			NewText: []byte(buf.String()),
+		}},
+	}, nil
+}
+
+// canSwitchToIf returns the switch statement whose header or case
+// expressions (that is, excluding the case bodies) enclose the range,
+// or an error if there is none, or it can't be converted to an
+// if/else-if chain.
+func canSwitchToIf(curFile inspector.Cursor, start, end token.Pos) (*ast.SwitchStmt, error) {
+	cur, _ := curFile.FindByPos(start, end)
+	stmt, cur := cursorutil.FirstEnclosing[*ast.SwitchStmt](cur)
+	if stmt == nil {
+		return nil, fmt.Errorf("not a switch statement")
+	}
+	if _, ok := cur.Parent().Node().(*ast.LabeledStmt); ok {
+		return nil, fmt.Errorf("switch statement is labeled")
+	}
+	if stmt.Tag != nil && !isSimpleTag(stmt.Tag) {
+		return nil, fmt.Errorf("switch tag %s is not a variable", types.ExprString(stmt.Tag))
+	}
+	cases := 0
+	for _, clause := range stmt.Body.List {
+		clause := clause.(*ast.CaseClause)
+		if clause.Colon < start && end <= clause.End() {
+			return nil, fmt.Errorf("selection is within a case body")
+		}
+		if n := len(clause.Body); n > 0 {
+			if branch, ok := clause.Body[n-1].(*ast.BranchStmt); ok && branch.Tok == token.FALLTHROUGH {
+				return nil, fmt.Errorf("case contains a fallthrough statement")
+			}
+		}
+		for _, stmt := range clause.Body {
+			if hasBreak(stmt) {
+				return nil, fmt.Errorf("case contains a break statement")
+			}
+		}
+		if clause.List != nil {
+			cases++
+		}
+	}
+	if cases == 0 {
+		return nil, fmt.Errorf("switch statement has no cases")
+	}
+	return stmt, nil
+}
+
+// hasBreak reports whether n contains a break statement without a label
+// that is not within a nested for, switch, or select statement, or a
+// function literal, that is, one that breaks an enclosing statement.
+func hasBreak(n ast.Node) bool {
+	found := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt, *ast.FuncLit:
+			return false
+		case *ast.BranchStmt:
+			if n.Tok == token.BREAK && n.Label == nil {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// hasCompositeLit reports whether e contains a composite literal, which
+// must be parenthesized in the condition of an if statement.
+func hasCompositeLit(e ast.Expr) bool {
+	found := false
+	ast.Inspect(e, func(n ast.Node) bool {
+		if _, ok := n.(*ast.CompositeLit); ok {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// blockText returns the text of block between its braces, as a body
+// indented by indent (see bodyText).
+func blockText(pgf *parsego.File, block *ast.BlockStmt, indent string) string {
+	return bodyText(posText(pgf, block.Lbrace+1, block.Rbrace), indent)
+}
+
+// bodyText returns the text of a block or case body, without trailing
+// space, and starting on a new line, unless it starts with a comment.
+func bodyText(text, indent string) string {
+	text = strings.TrimRight(text, " \t\n")
+	first, _, _ := strings.Cut(text, "\n")
+	if first = strings.TrimSpace(first); first != "" && !strings.HasPrefix(first, "//") && !strings.HasPrefix(first, "/*") {
+		text = "\n" + indent + "\t" + strings.TrimLeft(text, " \t")
+	}
+	return text
+}
+
+// lineIndent returns the indentation of the line containing pos.
+func lineIndent(pgf *parsego.File, pos token.Pos) string {
+	indent, _ := pgf.Indentation(pos) // can't fail: pos is within pgf
+	return indent
+}
+
+// posText returns the text of pgf in [start, end).
+func posText(pgf *parsego.File, start, end token.Pos) string {
+	text, _ := pgf.PosText(start, end) // can't fail: the range is within pgf
+	return string(text)
+}
+
+// strayComments returns the text of the comments of file within
+// [start, end) that are not within the given nodes, each preceded by a
+// space: the comments that would otherwise be lost when only the text
+// of the nodes is kept.
+func strayComments(file *ast.File, start, end token.Pos, nodes ...ast.Node) string {
+	var buf strings.Builder
+outer:
+	for _, group := range file.Comments {
+		if !posRangeContains(start, end, group.Pos(), group.End()) {
+			continue
+		}
+		for _, n := range nodes {
+			if posRangeContains(n.Pos(), n.End(), group.Pos(), group.End()) {
+				continue outer
+			}
+		}
+		for _, c := range group.List {
+			buf.WriteString(" " + c.Text)
+		}
+	}
+	return buf.String()
+}
@@ -9,7 +9,10 @@ import (
 	"fmt"
 	"go/ast"
 	"go/parser"
+	"go/token"
 	"go/types"
+	"slices"
+	"strings"
 
 	"golang.org/x/tools/go/ast/astutil"
 	"golang.org/x/tools/go/types/typeutil"
@@ -18,10 +21,12 @@ import (
 	"golang.org/x/tools/gopls/internal/protocol"
 	"golang.org/x/tools/gopls/internal/util/bug"
 	"golang.org/x/tools/gopls/internal/util/moremaps"
+	"golang.org/x/tools/gopls/internal/util/safetoken"
 	"golang.org/x/tools/internal/analysis/driverutil"
 	"golang.org/x/tools/internal/diff"
 	"golang.org/x/tools/internal/refactor"
 	"golang.org/x/tools/internal/refactor/inline"
+	"golang.org/x/tools/internal/typesinternal"
 )
 
 // inlineAllCalls inlines all calls to the original function declaration
@@ -117,9 +122,10 @@ func inlineAllCalls(ctx context.Context, snapshot *cache.Snapshot, pkg *cache.Pa
 	// declaration, we must re-type check.
 
 	type fileCalls struct {
-		pkg   *cache.Package
-		pgf   *parsego.File
-		calls []*ast.CallExpr
+		pkg    *cache.Package
+		pgf    *parsego.File
+		calls  []*ast.CallExpr
+		values []ast.Expr // references in non-call positions
 	}
 
 	refsByFile := make(map[protocol.DocumentURI]*fileCalls)
@@ -135,34 +141,54 @@ func inlineAllCalls(ctx context.Context, snapshot *cache.Snapshot, pkg *cache.Pa
 			return nil, err // e.g. invalid range
 		}
 
-		// Look for the surrounding call expression.
+		// Find the expression denoting the function (f, pkg.f, x.f or T.f,
+		// perhaps instantiated as f[T]), and the surrounding call, if any.
 		var (
 			name *ast.Ident
+			fun  ast.Expr
 			call *ast.CallExpr
 		)
 		path, _ := astutil.PathEnclosingInterval(pgf.File, start, end)
 		name, _ = path[0].(*ast.Ident)
-
-		// TODO(rfindley): handle method expressions correctly.
-		if _, ok := path[1].(*ast.SelectorExpr); ok {
-			call, _ = path[2].(*ast.CallExpr)
-		} else {
-			call, _ = path[1].(*ast.CallExpr)
+		if name == nil {
+			return nil, bug.Errorf("cannot inline: corrupted reference %v", ref)
 		}
-		if name == nil || call == nil {
-			// TODO(rfindley): handle this case with eta-abstraction:
-			// a reference to the target function f in a non-call position
-			//    use(f)
-			// is replaced by
-			//    use(func(...) { f(...) })
-			return nil, fmt.Errorf("cannot inline: found non-call function reference %v", ref)
+		fun = name
+	outer:
+		for _, n := range path[1:] {
+			switch n := n.(type) {
+			case *ast.SelectorExpr:
+				if n.Sel != fun {
+					break outer
+				}
+			case *ast.IndexExpr:
+				if n.X != fun {
+					break outer
+				}
+			case *ast.IndexListExpr:
+				if n.X != fun {
+					break outer
+				}
+			case *ast.CallExpr:
+				if n.Fun == fun {
+					call = n
+				}
+				break outer
+			default:
+				break outer
+			}
+			fun = n.(ast.Expr)
 		}
 
 		// Heuristic: ignore references that overlap with type checker errors, as they may
 		// lead to invalid results (see golang/go#70268).
+		var node ast.Node = fun
+		if call != nil {
+			node = call
+		}
 		hasTypeErrors := false
 		for _, typeErr := range refpkg.TypeErrors() {
-			if call.Lparen <= typeErr.Pos && typeErr.Pos <= call.Rparen {
+			if node.Pos() <= typeErr.Pos && typeErr.Pos <= node.End() {
 				hasTypeErrors = true
 			}
 		}
@@ -171,9 +197,12 @@ func inlineAllCalls(ctx context.Context, snapshot *cache.Snapshot, pkg *cache.Pa
 			continue
 		}
 
-		if typeutil.StaticCallee(refpkg.TypesInfo(), call) == nil {
+		if call != nil && typeutil.StaticCallee(refpkg.TypesInfo(), call) == nil {
 			continue // dynamic call
 		}
+		if call == nil && isInterfaceMethod(refpkg.TypesInfo().Uses[name]) {
+			continue // abstract method value, such as io.Reader.Read
+		}
 
 		// Sanity check.
 		if obj := refpkg.TypesInfo().ObjectOf(name); obj == nil ||
@@ -192,7 +221,30 @@ func inlineAllCalls(ctx context.Context, snapshot *cache.Snapshot, pkg *cache.Pa
 			}
 			refsByFile[ref.URI] = callInfo
 		}
-		callInfo.calls = append(callInfo.calls, call)
+		if call != nil {
+			callInfo.calls = append(callInfo.calls, call)
+		} else {
+			callInfo.values = append(callInfo.values, fun)
+		}
+	}
+
+	// targetCalls returns the static calls to the target function in
+	// file, in order.
+	target, _ := pkg.TypesInfo().Defs[origDecl.Name].(*types.Func)
+	if target == nil {
+		return nil, bug.Errorf("no object for %s", origDecl.Name.Name)
+	}
+	targetCalls := func(file *ast.File, info *types.Info) []*ast.CallExpr {
+		var calls []*ast.CallExpr
+		ast.Inspect(file, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok {
+				if fn := typeutil.StaticCallee(info, call); fn != nil && sameFunc(fn, target) {
+					calls = append(calls, call)
+				}
+			}
+			return true
+		})
+		return calls
 	}
 
 	// Inline each call within the same decl in sequence, re-typechecking after
@@ -212,6 +264,31 @@ func inlineAllCalls(ctx context.Context, snapshot *cache.Snapshot, pkg *cache.Pa
 			content = callInfo.pgf.Src
 		)
 
+		logf := func(string, ...any) {}
+		if opts != nil {
+			logf = opts.Logf
+		}
+
+		// Replace each reference to the function in a non-call position
+		// by a function literal that calls it, then inline that call
+		// along with the others.
+		if len(callInfo.values) > 0 {
+			var err error
+			content, err = etaAbstract(tpkg, tinfo, callInfo.pgf, callInfo.values)
+			if err != nil {
+				return nil, err
+			}
+			file, err = parser.ParseFile(fset, uri.Path(), content, parser.ParseComments|parser.SkipObjectResolution)
+			if err != nil {
+				return nil, bug.Errorf("eta-abstracted file failed to parse: %v", err)
+			}
+			tpkg, tinfo, err = reTypeCheck(logf, callInfo.pkg, map[protocol.DocumentURI]*ast.File{uri: file}, nil, false)
+			if err != nil {
+				return nil, fmt.Errorf("%s: replacing function values by function literals: %v", uri.Path(), err)
+			}
+			calls = targetCalls(file, tinfo)
+		}
+
 		// Check for overlapping calls (such as Foo(Foo())). We can't handle these
 		// because inlining may change the source order of the inner call with
 		// respect to the inlined outer call, and so the heuristic we use to find
@@ -298,26 +375,13 @@ func inlineAllCalls(ctx context.Context, snapshot *cache.Snapshot, pkg *cache.Pa
 			// anything in the surrounding scope.
 			//
 			// TODO(rfindley): improve this.
-			logf := func(string, ...any) {}
-			if opts != nil {
-				logf = opts.Logf
-			}
-			tpkg, tinfo, err = reTypeCheck(logf, callInfo.pkg, map[protocol.DocumentURI]*ast.File{uri: file}, true)
+			tpkg, tinfo, err = reTypeCheck(logf, callInfo.pkg, map[protocol.DocumentURI]*ast.File{uri: file}, nil, true)
 			if err != nil {
 				return nil, bug.Errorf("type checking after inlining failed: %v", err)
 			}
 
 			// Collect calls to the target function in the modified declaration.
-			var calls2 []*ast.CallExpr
-			ast.Inspect(file, func(n ast.Node) bool {
-				if call, ok := n.(*ast.CallExpr); ok {
-					fn := typeutil.StaticCallee(tinfo, call)
-					if fn != nil && fn.Pkg().Path() == string(pkg.Metadata().PkgPath) && fn.Name() == origDecl.Name.Name {
-						calls2 = append(calls2, call)
-					}
-				}
-				return true
-			})
+			calls2 := targetCalls(file, tinfo)
 
 			// If the number of calls has increased, this process will never cease.
 			// If the number of calls has decreased, assume that inlining removed a
@@ -346,3 +410,231 @@ func inlineAllCalls(ctx context.Context, snapshot *cache.Snapshot, pkg *cache.Pa
 	}
 	return result, nil
 }
+
+// etaAbstract returns the content of pgf after replacing each of the
+// given references to a function in non-call positions by its
+// eta-abstraction, an equivalent function literal that calls it, such as
+//
+//	func(x int) (bool, error) { return f(x) }
+//
+// so that the call may then be inlined.
+//
+// A method value x.f is replaced only if its receiver x is a local
+// variable whose value can't change between the evaluation of the method
+// value and the calls of the function literal.
+func etaAbstract(pkg *types.Package, info *types.Info, pgf *parsego.File, values []ast.Expr) ([]byte, error) {
+	var (
+		edits    []diff.Edit
+		prefixes = make(map[string]string) // qualifiers of imported packages, by path
+	)
+	for _, value := range values {
+		sig, ok := info.TypeOf(value).Underlying().(*types.Signature)
+		if !ok {
+			return nil, bug.Errorf("function value %s has type %v", types.ExprString(value), info.TypeOf(value))
+		}
+		start, end, err := safetoken.Offsets(pgf.Tok, value.Pos(), value.End())
+		if err != nil {
+			return nil, err
+		}
+		fun := string(pgf.Src[start:end])
+
+		// Avoid parameter names that would shadow the identifiers of the
+		// function value, or the package qualifiers of its type.
+		used := make(map[string]bool)
+		for n := range ast.Preorder(value) {
+			if id, ok := n.(*ast.Ident); ok {
+				used[id.Name] = true
+			}
+		}
+		qual := func(p *types.Package) string {
+			if p == pkg {
+				return ""
+			}
+			prefix, ok := prefixes[p.Path()]
+			if !ok {
+				var edits2 []refactor.Edit
+				prefix, edits2 = refactor.AddImport(info, pgf.File, p.Name(), p.Path(), "", value.Pos())
+				prefixes[p.Path()] = prefix
+				edits = append(edits, diffEdits(pgf.File, edits2)...)
+			}
+			name := strings.TrimSuffix(prefix, ".")
+			used[name] = true
+			return name
+		}
+		typeString := func(i int) string {
+			t := sig.Params().At(i).Type()
+			if sig.Variadic() && i == sig.Params().Len()-1 {
+				return "..." + types.TypeString(t.(*types.Slice).Elem(), qual)
+			}
+			return types.TypeString(t, qual)
+		}
+		var (
+			paramTypes  []string
+			resultTypes []string
+		)
+		for i := range sig.Params().Len() {
+			paramTypes = append(paramTypes, typeString(i))
+		}
+		for v := range sig.Results().Variables() {
+			resultTypes = append(resultTypes, types.TypeString(v.Type(), qual))
+		}
+		fresh := func(name, fallback string) string {
+			if name == "" || name == "_" {
+				name = fallback
+			}
+			base := name
+			for i := 1; used[name]; i++ {
+				name = fmt.Sprintf("%s%d", base, i)
+			}
+			used[name] = true
+			return name
+		}
+
+		// A method value x.f must be evaluated, as x.f(...), within the
+		// function literal; a method expression T.f is called as
+		// recv.f(...) on the first parameter.
+		first := 0 // index of the first argument
+		if sel, ok := value.(*ast.SelectorExpr); ok {
+			if seln := info.Selections[sel]; seln != nil {
+				switch seln.Kind() {
+				case types.MethodVal:
+					if !stableReceiver(info, pgf, sel, seln) {
+						return nil, fmt.Errorf("cannot rewrite method value %s: its receiver may change before the method is called", types.ExprString(value))
+					}
+				case types.MethodExpr:
+					first = 1
+				}
+			}
+		}
+		var params, args []string
+		for i := range sig.Params().Len() {
+			name := fresh(sig.Params().At(i).Name(), "x")
+			params = append(params, name+" "+paramTypes[i])
+			if i < first {
+				fun = name + "." + value.(*ast.SelectorExpr).Sel.Name
+				continue
+			}
+			if sig.Variadic() && i == sig.Params().Len()-1 {
+				name += "..."
+			}
+			args = append(args, name)
+		}
+
+		var lit strings.Builder
+		fmt.Fprintf(&lit, "func(%s) ", strings.Join(params, ", "))
+		switch len(resultTypes) {
+		case 0:
+			fmt.Fprintf(&lit, "{ %s(%s) }", fun, strings.Join(args, ", "))
+		case 1:
+			fmt.Fprintf(&lit, "%s { return %s(%s) }", resultTypes[0], fun, strings.Join(args, ", "))
+		default:
+			fmt.Fprintf(&lit, "(%s) { return %s(%s) }", strings.Join(resultTypes, ", "), fun, strings.Join(args, ", "))
+		}
+		edits = append(edits, diff.Edit{Start: start, End: end, New: lit.String()})
+	}
+	return diff.ApplyBytes(pgf.Src, edits)
+}
+
+// stableReceiver reports whether the receiver x of the method value x.f
+// denotes the same value at every call of a function literal that calls
+// x.f as when the method value is evaluated: x must be a local variable
+// that is never reassigned and, if the method value would hold a copy of
+// it, never modified.
+func stableReceiver(info *types.Info, pgf *parsego.File, sel *ast.SelectorExpr, seln *types.Selection) bool {
+	id, ok := ast.Unparen(sel.X).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	v, ok := info.Uses[id].(*types.Var)
+	if !ok || v.IsField() || typesinternal.IsPackageLevel(v) {
+		return false
+	}
+
+	// The method value holds a copy of x, unless x is an interface, or f
+	// has a pointer receiver (whose address is taken, if need be).
+	// A copy of *x may be modified through other pointers, so we give up.
+	ptrRecv, _ := typesinternal.ReceiverNamed(seln.Obj().(*types.Func).Signature().Recv())
+	copies := !ptrRecv && (!types.IsInterface(v.Type()) || is[*types.TypeParam](v.Type())) ||
+		len(seln.Index()) > 1 // promoted through an embedded field
+	if copies && is[*types.Pointer](v.Type().Underlying()) {
+		return false
+	}
+
+	for cur := range pgf.Cursor().Preorder((*ast.Ident)(nil)) {
+		if info.Uses[cur.Node().(*ast.Ident)] != v {
+			continue
+		}
+		// If x is copied, find the part of it (such as x.a[1].b) that
+		// this use may modify.
+	climb:
+		for copies {
+			switch p := cur.Parent().Node().(type) {
+			case *ast.ParenExpr:
+			case *ast.SelectorExpr:
+				if seln := info.Selections[p]; seln == nil || seln.Kind() != types.FieldVal || seln.Indirect() {
+					break climb
+				}
+			case *ast.IndexExpr:
+				if p.X != cur.Node() || !is[*types.Array](info.TypeOf(p.X).Underlying()) {
+					break climb
+				}
+			default:
+				break climb
+			}
+			cur = cur.Parent()
+		}
+		e := cur.Node()
+		switch p := cur.Parent().Node().(type) {
+		case *ast.AssignStmt:
+			if slices.Contains(p.Lhs, e.(ast.Expr)) {
+				return false
+			}
+		case *ast.IncDecStmt:
+			return false
+		case *ast.RangeStmt:
+			if p.Tok == token.ASSIGN && (p.Key == e || p.Value == e) {
+				return false
+			}
+		case *ast.UnaryExpr:
+			if p.Op == token.AND {
+				return false
+			}
+		case *ast.SelectorExpr:
+			// A call of a pointer method takes the address of its
+			// receiver implicitly.
+			if seln := info.Selections[p]; copies && seln != nil && seln.Kind() == types.MethodVal {
+				if ptr, _ := typesinternal.ReceiverNamed(seln.Obj().(*types.Func).Signature().Recv()); ptr && !seln.Indirect() {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// isInterfaceMethod reports whether obj is an abstract method.
+func isInterfaceMethod(obj types.Object) bool {
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	recv := fn.Signature().Recv()
+	return recv != nil && types.IsInterface(recv.Type())
+}
+
+// sameFunc reports whether x and y denote the same function or method,
+// perhaps in different type-checkings of the same package.
+func sameFunc(x, y *types.Func) bool {
+	if x.Pkg() == nil || y.Pkg() == nil || x.Pkg().Path() != y.Pkg().Path() || x.Name() != y.Name() {
+		return false
+	}
+	recvName := func(fn *types.Func) string {
+		if recv := fn.Signature().Recv(); recv != nil {
+			if _, named := typesinternal.ReceiverNamed(recv); named != nil {
+				return named.Obj().Name()
+			}
+		}
+		return ""
+	}
+	return recvName(x) == recvName(y)
+}
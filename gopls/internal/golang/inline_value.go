@@ -0,0 +1,130 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/file"
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/internal/event"
+	"golang.org/x/tools/internal/moreiters"
+)
+
+// InlineValue returns the variables and expressions within the
+// visible range rng whose values a debugger stopped at the start of
+// the stopped range should display inline.
+//
+// The values are the occurrences of local variables (including
+// parameters, results, and loop variables) of the function
+// containing the stopped location, along with the fields of its
+// receiver, up to and including the stopped line. A variable is
+// reported only if the stopped location is within its scope and it is
+// not shadowed there, so that the debugger's lookup by name yields
+// the value of the same variable.
+func InlineValue(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle, rng, stopped protocol.Range) ([]protocol.InlineValue, error) {
+	ctx, done := event.Start(ctx, "golang.InlineValue")
+	defer done()
+
+	pkg, pgf, err := NarrowestPackageForFile(ctx, snapshot, fh.URI())
+	if err != nil {
+		return nil, fmt.Errorf("getting package for InlineValue: %w", err)
+	}
+	start, end, err := pgf.RangePos(rng)
+	if err != nil {
+		return nil, err
+	}
+	stop, _, err := pgf.RangePos(stopped)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the innermost function containing the stopped location.
+	// Only it and the variables it captures are accessible
+	// in the debugger's stack frame.
+	curStop, ok := pgf.Cursor().FindByPos(stop, stop)
+	if !ok {
+		return nil, nil
+	}
+	curFunc, ok := moreiters.First(curStop.Enclosing((*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)))
+	if !ok {
+		return nil, nil // not within a function
+	}
+	scope := pkg.Types().Scope().Innermost(stop)
+	if scope == nil {
+		return nil, nil
+	}
+
+	info := pkg.TypesInfo()
+
+	// visible reports whether v is a local variable that is
+	// in scope, and not shadowed, at the stopped location.
+	visible := func(v *types.Var) bool {
+		if v.IsField() || v.Name() == "_" || v.Pkg() == nil || v.Parent() == v.Pkg().Scope() {
+			return false
+		}
+		_, obj := scope.LookupParent(v.Name(), stop)
+		return obj == v
+	}
+
+	var recv *types.Var
+	if decl, ok := curFunc.Node().(*ast.FuncDecl); ok && decl.Recv != nil {
+		if names := decl.Recv.List[0].Names; len(names) > 0 {
+			recv, _ = info.Defs[names[0]].(*types.Var)
+		}
+	}
+
+	var values []protocol.InlineValue
+	add := func(n ast.Node, value func(protocol.Range) any) {
+		if n.Pos() < start || n.End() > end {
+			return // outside requested range
+		}
+		rng, err := pgf.NodeRange(n)
+		if err != nil || rng.Start.Line > stopped.End.Line {
+			return // after stopped line
+		}
+		values = append(values, protocol.InlineValue{Value: value(rng)})
+	}
+	curFunc.Inspect([]ast.Node{(*ast.Ident)(nil), (*ast.SelectorExpr)(nil)}, func(cur inspector.Cursor) bool {
+		switch n := cur.Node().(type) {
+		case *ast.SelectorExpr:
+			// A field of the receiver?
+			if recv == nil {
+				break
+			}
+			x, ok := n.X.(*ast.Ident)
+			if !ok || info.Uses[x] != recv || !visible(recv) {
+				break
+			}
+			if sel, ok := info.Selections[n]; ok && sel.Kind() == types.FieldVal {
+				add(n, func(rng protocol.Range) any {
+					return protocol.InlineValueEvaluatableExpression{
+						Range:      rng,
+						Expression: types.ExprString(n),
+					}
+				})
+				return false // don't also report the receiver
+			}
+
+		case *ast.Ident:
+			if v, ok := info.ObjectOf(n).(*types.Var); ok && visible(v) {
+				add(n, func(rng protocol.Range) any {
+					return protocol.InlineValueVariableLookup{
+						Range:               rng,
+						VariableName:        n.Name,
+						CaseSensitiveLookup: true,
+					}
+				})
+			}
+		}
+		return true
+	})
+	return values, nil
+}
@@ -0,0 +1,206 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+
+	"golang.org/x/tools/go/ast/edge"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/file"
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/util/cursorutil"
+	"golang.org/x/tools/internal/astutil"
+	"golang.org/x/tools/internal/event"
+)
+
+// LinkedEditingRange returns the ranges that may be edited together
+// with the identifier or struct tag key at the selected range, or nil
+// if there are none.
+//
+// For a local variable, parameter, result, or label, the ranges are
+// its declaration and all its uses, all of which lie within the
+// enclosing function, so editing them together is equivalent to a
+// (trivially safe) renaming. For the key of a conventional
+// key:"value" pair in a struct field tag, the ranges are all
+// occurrences of that key in the tags of the same struct type.
+func LinkedEditingRange(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle, rng protocol.Range) (*protocol.LinkedEditingRanges, error) {
+	ctx, done := event.Start(ctx, "golang.LinkedEditingRange")
+	defer done()
+
+	pkg, pgf, err := NarrowestPackageForFile(ctx, snapshot, fh.URI())
+	if err != nil {
+		return nil, fmt.Errorf("getting package for LinkedEditingRange: %w", err)
+	}
+	start, end, err := pgf.RangePos(rng)
+	if err != nil {
+		return nil, err
+	}
+
+	cur, _, _, _ := astutil.Select(pgf.Cursor(), start, end) // can't fail: pgf contains pos
+
+	var linked []astutil.Range
+	switch cur.Node().(type) {
+	case *ast.Ident:
+		linked = linkedLocalIdents(pkg.TypesInfo(), cur)
+	case *ast.BasicLit:
+		linked = linkedStructTagKeys(cur, astutil.RangeOf(start, end))
+	}
+	if len(linked) == 0 {
+		return nil, nil
+	}
+
+	var ranges []protocol.Range
+	for _, r := range linked {
+		rng, err := pgf.NodeRange(r)
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, rng)
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		return protocol.CompareRange(ranges[i], ranges[j]) < 0
+	})
+	return &protocol.LinkedEditingRanges{Ranges: ranges}, nil
+}
+
+// linkedLocalIdents returns the ranges of the declaration and uses of
+// the local variable or label denoted by the identifier at cur, or nil
+// if it denotes some other kind of symbol.
+func linkedLocalIdents(info *types.Info, cur inspector.Cursor) []astutil.Range {
+	id := cur.Node().(*ast.Ident)
+	switch obj := info.ObjectOf(id).(type) {
+	case *types.Var:
+		if obj.IsField() || obj.Pkg() == nil || obj.Parent() == obj.Pkg().Scope() {
+			return nil // not a local variable
+		}
+		// The symbolic variable x of a type switch "switch x := y.(type)"
+		// is declared by an identifier with no Defs entry, and each case
+		// clause has its own implicit object; don't attempt to link them.
+		_, curFile := cursorutil.FirstEnclosing[*ast.File](cur)
+		curDecl, ok := curFile.FindByPos(obj.Pos(), obj.Pos())
+		if !ok {
+			return nil
+		}
+		if decl, ok := curDecl.Node().(*ast.Ident); !ok || info.Defs[decl] != obj {
+			return nil
+		}
+	case *types.Label:
+		// ok
+	default:
+		return nil
+	}
+
+	// Reuse the logic of highlighting, which reports the
+	// declaration and all uses of the object within the file.
+	result := make(map[astutil.Range]protocol.DocumentHighlightKind)
+	highlightIdentifier(cur, info, result)
+	linked := make([]astutil.Range, 0, len(result))
+	for rng := range result {
+		linked = append(linked, rng)
+	}
+	return linked
+}
+
+// linkedStructTagKeys returns the ranges of all keys, in the tags of the
+// fields of the enclosing struct type, equal to the struct tag key
+// containing rng, or nil if cur is not a struct field tag or rng is not
+// within a key.
+func linkedStructTagKeys(cur inspector.Cursor, rng astutil.Range) []astutil.Range {
+	if ek, _ := cur.ParentEdge(); ek != edge.Field_Tag {
+		return nil
+	}
+	curFieldList := cur.Parent().Parent()
+	if ek, _ := curFieldList.ParentEdge(); ek != edge.StructType_Fields {
+		return nil
+	}
+
+	var key string
+	for _, k := range structTagKeys(cur.Node().(*ast.BasicLit)) {
+		if k.rng.Contains(rng) {
+			key = k.name
+			break
+		}
+	}
+	if key == "" {
+		return nil // not within a key
+	}
+
+	var linked []astutil.Range
+	for _, field := range curFieldList.Node().(*ast.FieldList).List {
+		if field.Tag != nil {
+			for _, k := range structTagKeys(field.Tag) {
+				if k.name == key {
+					linked = append(linked, k.rng)
+				}
+			}
+		}
+	}
+	return linked
+}
+
+// A structTagKey is the key of a key:"value" pair in a struct tag.
+type structTagKey struct {
+	name string
+	rng  astutil.Range
+}
+
+// structTagKeys returns the keys of the conventional key:"value"
+// pairs of the struct tag literal, following the parsing rules of
+// [reflect.StructTag.Lookup]. Only raw string literals are
+// supported, as the keys of an interpreted string literal may not
+// correspond to its source text.
+func structTagKeys(lit *ast.BasicLit) []structTagKey {
+	if lit.Kind != token.STRING || len(lit.Value) < 2 || lit.Value[0] != '`' {
+		return nil
+	}
+	var (
+		keys []structTagKey
+		tag  = lit.Value[1 : len(lit.Value)-1]
+		pos  = lit.Pos() + 1
+	)
+	for tag != "" {
+		// Skip leading space.
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+		tag, pos = tag[i:], pos+token.Pos(i)
+		if tag == "" {
+			break
+		}
+
+		// Scan to colon. A space, a quote or a control character is a syntax error.
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+		keys = append(keys, structTagKey{tag[:i], astutil.RangeOf(pos, pos+token.Pos(i))})
+		tag, pos = tag[i+1:], pos+token.Pos(i+1)
+
+		// Scan quoted string to find value.
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+		tag, pos = tag[i+1:], pos+token.Pos(i+1)
+	}
+	return keys
+}
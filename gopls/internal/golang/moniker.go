@@ -0,0 +1,228 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"context"
+	"fmt"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/types/objectpath"
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/cache/metadata"
+	"golang.org/x/tools/gopls/internal/file"
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/util/safetoken"
+	"golang.org/x/tools/internal/astutil"
+	"golang.org/x/tools/internal/event"
+	"golang.org/x/tools/internal/packagepath"
+	"golang.org/x/tools/internal/typesinternal"
+)
+
+// monikerScheme is the scheme of all monikers reported by gopls.
+const monikerScheme = "go"
+
+// Moniker returns the monikers for the symbol declared or referenced at
+// the selected identifier, for use by LSIF or SCIP indexers.
+//
+// The identifier of a moniker has the form
+//
+//	<module path> <package path> <symbol>
+//
+// where the module path is "std" for the standard library. The symbol
+// is the name of a package-level object, the qualified name T.M or T.F
+// of a method or field of a package-level named type, or otherwise the
+// [objectpath] encoding of the object. Such identifiers are globally
+// unique, so that references in one repository can be linked to
+// declarations in another. Symbols that have no object path, such as
+// local variables, are identified by their name and declaration offset,
+// which is unique only within the document.
+//
+// The moniker kind is "import" for symbols declared in another module,
+// "export" for exported symbols of the current module, and "local" for
+// all others.
+func Moniker(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle, rng protocol.Range) ([]protocol.Moniker, error) {
+	ctx, done := event.Start(ctx, "golang.Moniker")
+	defer done()
+
+	pkg, pgf, err := NarrowestPackageForFile(ctx, snapshot, fh.URI())
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := pgf.RangePos(rng)
+	if err != nil {
+		return nil, err
+	}
+
+	// The name in the package clause denotes the package itself.
+	if pgf.File.Name != nil && astutil.NodeContains(pgf.File.Name, astutil.RangeOf(start, end)) {
+		return []protocol.Moniker{packageMoniker(snapshot, pkg.Metadata(), pkg.Types().Path())}, nil
+	}
+
+	cur, _ := pgf.Cursor().FindByPos(start, end) // can't fail
+	objects, err := objectsAt(pkg.TypesInfo(), cur)
+	if err != nil {
+		return nil, nil // no identifier
+	}
+	var monikers []protocol.Moniker
+	seen := make(map[string]bool) // identifiers
+	for _, o := range objects {
+		m := objectMoniker(snapshot, pkg, o.obj)
+		if !seen[m.Identifier] {
+			seen[m.Identifier] = true
+			monikers = append(monikers, m)
+		}
+	}
+	return monikers, nil
+}
+
+// packageMoniker returns the moniker for the package with the given
+// path, as seen from the package described by from.
+func packageMoniker(snapshot *cache.Snapshot, from *metadata.Package, path string) protocol.Moniker {
+	modPath := modulePathOf(snapshot, path)
+	return newMoniker(monikerIdentifier(modPath, path, ""), monikerUniqueness(modPath), monikerKind(from, modPath, true))
+}
+
+// objectMoniker returns the moniker for obj, as seen from package pkg.
+func objectMoniker(snapshot *cache.Snapshot, pkg *cache.Package, obj types.Object) protocol.Moniker {
+	obj = origin(obj)
+	if pkgname, ok := obj.(*types.PkgName); ok {
+		return packageMoniker(snapshot, pkg.Metadata(), pkgname.Imported().Path())
+	}
+	if obj.Pkg() == nil {
+		// Predeclared objects such as error or len belong to no package.
+		return newMoniker(monikerIdentifier("std", "builtin", obj.Name()), protocol.Global, protocol.Import)
+	}
+
+	sym, exported, ok := monikerSymbol(obj)
+	if !ok {
+		// A symbol with no object path is local to its declaring file.
+		posn := safetoken.StartPosition(pkg.FileSet(), obj.Pos())
+		return newMoniker(fmt.Sprintf("%s:%d", obj.Name(), posn.Offset), protocol.Document, protocol.Local)
+	}
+	modPath := modulePathOf(snapshot, obj.Pkg().Path())
+	return newMoniker(monikerIdentifier(modPath, obj.Pkg().Path(), sym), monikerUniqueness(modPath), monikerKind(pkg.Metadata(), modPath, exported))
+}
+
+// monikerSymbol returns the symbol component of the moniker identifier
+// for obj, and reports whether the symbol is accessible from other
+// packages. It returns ok=false if obj has no object path.
+func monikerSymbol(obj types.Object) (sym string, exported, ok bool) {
+	path, err := objectpath.For(obj)
+	if err != nil {
+		return "", false, false
+	}
+	scope := obj.Pkg().Scope()
+	if obj.Parent() == scope {
+		return obj.Name(), obj.Exported(), true
+	}
+
+	// Use the qualified name T.M or T.F for a method or
+	// field of a package-level named type T.
+	var tname *types.TypeName
+	switch obj := obj.(type) {
+	case *types.Func:
+		if recv := obj.Signature().Recv(); recv != nil {
+			if _, named := typesinternal.ReceiverNamed(recv); named != nil {
+				tname = named.Obj()
+			}
+		}
+	case *types.Var:
+		if obj.IsField() {
+			tname = fieldOwner(scope, obj)
+		}
+	}
+	if tname != nil && tname.Parent() == scope {
+		return tname.Name() + "." + obj.Name(), tname.Exported() && obj.Exported(), true
+	}
+	return string(path), obj.Exported(), true
+}
+
+// fieldOwner returns the package-level named struct type that
+// directly declares the field, or nil if there is none.
+func fieldOwner(scope *types.Scope, field *types.Var) *types.TypeName {
+	for _, name := range scope.Names() {
+		tname, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || tname.IsAlias() {
+			continue
+		}
+		if s, ok := tname.Type().Underlying().(*types.Struct); ok {
+			for f := range s.Fields() {
+				if f == field {
+					return tname
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// modulePathOf returns the path of the module containing the package
+// with the given path: "std" for the standard library, or "" if unknown.
+func modulePathOf(snapshot *cache.Snapshot, pkgPath string) string {
+	for _, mp := range snapshot.MetadataGraph().ForPackagePath[metadata.PackagePath(pkgPath)] {
+		if mp.Module != nil {
+			return mp.Module.Path
+		}
+	}
+	if packagepath.IsStdPackage(pkgPath) {
+		return "std"
+	}
+	return ""
+}
+
+// monikerIdentifier returns the identifier of a moniker for the
+// given module, package, and (optional) symbol.
+func monikerIdentifier(modPath, pkgPath, sym string) string {
+	var parts []string
+	if modPath != "" {
+		parts = append(parts, modPath)
+	}
+	parts = append(parts, pkgPath)
+	if sym != "" {
+		parts = append(parts, sym)
+	}
+	return strings.Join(parts, " ")
+}
+
+// monikerUniqueness returns the uniqueness level of a moniker
+// identifier for a symbol in the given module.
+// Without the module path, the identifier is unique only within the
+// project.
+func monikerUniqueness(modPath string) protocol.UniquenessLevel {
+	if modPath == "" {
+		return protocol.Project
+	}
+	return protocol.Global
+}
+
+// monikerKind returns the kind of the moniker for a symbol of the
+// given module, as seen from the package described by from.
+func monikerKind(from *metadata.Package, modPath string, exported bool) protocol.MonikerKind {
+	var fromModPath string
+	if from.Module != nil {
+		fromModPath = from.Module.Path
+	} else if packagepath.IsStdPackage(string(from.PkgPath)) {
+		fromModPath = "std"
+	}
+	switch {
+	case modPath != fromModPath:
+		return protocol.Import
+	case exported:
+		return protocol.Export
+	default:
+		return protocol.Local
+	}
+}
+
+func newMoniker(identifier string, unique protocol.UniquenessLevel, kind protocol.MonikerKind) protocol.Moniker {
+	return protocol.Moniker{
+		Scheme:     monikerScheme,
+		Identifier: identifier,
+		Unique:     unique,
+		Kind:       &kind,
+	}
+}
@@ -0,0 +1,393 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package golang
+
+// This file defines the "introduce parameter struct" operation, which
+// replaces selected parameters of a function by a single parameter of
+// a new struct type:
+//
+//	func Dial(addr string, timeout time.Duration, retries int) error
+//
+// becomes
+//
+//	// DialOptions holds parameters of [Dial].
+//	type DialOptions struct {
+//		Timeout time.Duration
+//		Retries int
+//	}
+//
+//	func Dial(addr string, opts DialOptions) error
+//
+// Like change signature (see rewriteCalls), it declares the new
+// function under a tagged name, alongside a wrapper with the original
+// signature, which delegates to it by passing a keyed struct literal,
+// and then inlines all calls of the wrapper.
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/gopls/internal/analysis/fillstruct"
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/cache/parsego"
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/util/bug"
+	"golang.org/x/tools/gopls/internal/util/safetoken"
+	"golang.org/x/tools/internal/astutil"
+	"golang.org/x/tools/internal/diff"
+	"golang.org/x/tools/internal/typesinternal"
+)
+
+// A paramStruct describes the parameters of a function declaration to
+// collect into a struct.
+type paramStruct struct {
+	decl       *ast.FuncDecl
+	fn         *types.Func
+	name       string   // name of the struct type
+	start, end int      // selected parameters, as indices of the flattened parameters
+	fields     []string // field names of the selected parameters
+}
+
+// selectedParamStruct returns the parameter struct of the parameters of
+// a function declaration that intersect the selection [start, end), or
+// an error if they can't be collected into a struct.
+func selectedParamStruct(pkg *cache.Package, pgf *parsego.File, start, end token.Pos) (*paramStruct, error) {
+	var decl *ast.FuncDecl
+	for _, d := range pgf.File.Decls {
+		if d, ok := d.(*ast.FuncDecl); ok && d.Type.Params.Opening < start && end <= d.Type.Params.Closing {
+			decl = d
+			break
+		}
+	}
+	if decl == nil {
+		return nil, fmt.Errorf("selection is not within the parameters of a function declaration")
+	}
+	fn, ok := pkg.TypesInfo().Defs[decl.Name].(*types.Func)
+	if !ok || decl.Body == nil {
+		return nil, fmt.Errorf("%s has no body", decl.Name.Name)
+	}
+	if decl.Type.TypeParams != nil || fn.Signature().RecvTypeParams().Len() > 0 {
+		return nil, fmt.Errorf("cannot collect the parameters of generic function %s", fn.Name())
+	}
+
+	ps := &paramStruct{decl: decl, fn: fn, start: -1}
+	i := 0
+	for id, field := range astutil.FlatFields(decl.Type.Params) {
+		// The extent of a parameter includes its type only if it is the
+		// last one of its field.
+		pos, pend := field.Pos(), field.End()
+		if id != nil {
+			pos = id.Pos()
+			if id != field.Names[len(field.Names)-1] {
+				pend = id.End()
+			}
+		}
+		if pos <= end && start <= pend {
+			if id == nil || id.Name == "_" {
+				return nil, fmt.Errorf("cannot collect unnamed parameters")
+			}
+			if is[*ast.Ellipsis](field.Type) {
+				return nil, fmt.Errorf("cannot collect variadic parameter %s", id.Name)
+			}
+			if ps.start < 0 {
+				ps.start = i
+			}
+			ps.end = i + 1
+			ps.fields = append(ps.fields, id.Name)
+		}
+		i++
+	}
+	if len(ps.fields) < 2 {
+		return nil, fmt.Errorf("fewer than two parameters selected")
+	}
+
+	// The struct and its fields are exported if the function is.
+	ps.name = fn.Name() + "Options"
+	if fn.Exported() {
+		seen := make(map[string]bool)
+		for i, name := range ps.fields {
+			r, size := utf8.DecodeRuneInString(name)
+			name = string(unicode.ToUpper(r)) + name[size:]
+			if seen[name] {
+				return nil, fmt.Errorf("parameters would both become field %s", name)
+			}
+			seen[name] = true
+			ps.fields[i] = name
+		}
+	}
+	if pkg.Types().Scope().Lookup(ps.name) != nil {
+		return nil, fmt.Errorf("%s is already declared", ps.name)
+	}
+	return ps, nil
+}
+
+// IntroduceParamStruct replaces the parameters of a function
+// declaration that intersect rng by a parameter of a new struct type,
+// and updates all calls of the function to pass a struct literal.
+func IntroduceParamStruct(ctx context.Context, snapshot *cache.Snapshot, pkg *cache.Package, pgf *parsego.File, rng protocol.Range) ([]protocol.DocumentChange, error) {
+	if err := checkPackageErrors(pkg, "introduce a parameter struct"); err != nil {
+		return nil, err
+	}
+	start, end, err := pgf.RangePos(rng)
+	if err != nil {
+		return nil, err
+	}
+	ps, err := selectedParamStruct(pkg, pgf, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if ps.decl.Recv != nil {
+		if err := checkMethodUnneeded(ctx, snapshot, pgf.URI, ps.fn); err != nil {
+			return nil, fmt.Errorf("cannot change the signature of method %s: %v", ps.fn.Name(), err)
+		}
+	}
+
+	if ps.decl.Recv == nil && pkg.Types().Scope().Lookup(delegateTag+ps.fn.Name()) != nil {
+		return nil, fmt.Errorf("synthetic name %s%s conflicts with an existing declaration", delegateTag, ps.fn.Name())
+	}
+
+	// Step 1: declare the struct type and the new function, under a
+	// tagged name, and append the wrapper.
+	newContent := make(map[protocol.DocumentURI][]byte)
+	{
+		edits, err := ps.edits(pkg, pgf)
+		if err != nil {
+			return nil, err
+		}
+		src, err := diff.ApplyBytes(pgf.Src, edits)
+		if err != nil {
+			return nil, bug.Errorf("invalid edits to %s: %v", pgf.URI, err)
+		}
+		newContent[pgf.URI] = src
+	}
+
+	// Step 2: inline all calls to the wrapper, and delete it.
+	logf := logger(ctx, "introduce parameter struct", snapshot.Options().VerboseOutput)
+	if err := inlineWrapper(ctx, snapshot, pgf.URI, ps.fn.Name(), "introduce "+ps.name, logf, newContent); err != nil {
+		return nil, err
+	}
+
+	// Finally, remove the tag, and break the new struct literals into
+	// lines.
+	for uri, src := range newContent {
+		src = bytes.ReplaceAll(src, []byte(delegateTag), nil)
+		src, err := ps.splitLiterals(uri, src)
+		if err != nil {
+			return nil, err
+		}
+		if uri == pgf.URI {
+			if src, err = format.Source(src); err != nil {
+				return nil, bug.Errorf("formatting declaring file: %v", err)
+			}
+		}
+		newContent[uri] = src
+	}
+
+	return documentChanges(ctx, snapshot, newContent)
+}
+
+// edits returns the edits to the declaring file that declare the
+// struct type before the function, rename the function by prefixing
+// delegateTag, replace the selected parameters by one of the struct type and
+// their uses by the struct fields, and append a wrapper with the
+// original signature that calls the tagged function.
+func (ps *paramStruct) edits(pkg *cache.Package, pgf *parsego.File) ([]diff.Edit, error) {
+	var (
+		decl   = ps.decl
+		info   = pkg.TypesInfo()
+		edits  []diff.Edit
+		offset = func(pos token.Pos) int { return int(pos - pgf.File.FileStart) }
+		text   = func(n ast.Node) string {
+			text, _ := pgf.NodeText(n) // can't fail: n is within decl
+			return string(text)
+		}
+	)
+
+	// The struct type.
+	{
+		var buf strings.Builder
+		declName := ps.fn.Name()
+		if decl.Recv != nil {
+			_, named := typesinternal.ReceiverNamed(ps.fn.Signature().Recv())
+			declName = named.Obj().Name() + "." + declName
+		}
+		fmt.Fprintf(&buf, "// %s holds parameters of [%s].\n", ps.name, declName)
+		fmt.Fprintf(&buf, "type %s struct {\n", ps.name)
+		i := 0
+		for _, field := range astutil.FlatFields(decl.Type.Params) {
+			if ps.start <= i && i < ps.end {
+				fmt.Fprintf(&buf, "\t%s %s\n", ps.fields[i-ps.start], text(field.Type))
+			}
+			i++
+		}
+		buf.WriteString("}\n\n")
+		pos := decl.Pos()
+		if decl.Doc != nil {
+			pos = decl.Doc.Pos()
+		}
+		edits = append(edits, diff.Edit{Start: offset(pos), End: offset(pos), New: buf.String()})
+	}
+
+	// Choose the name of the new parameter, avoiding those of the other
+	// parameters and results, and of the objects used in the body.
+	used := make(map[string]bool)
+	ast.Inspect(decl, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			used[id.Name] = true
+		}
+		return true
+	})
+	opts := "opts"
+	for i := 0; used[opts]; i++ {
+		opts = fmt.Sprintf("opts%d", i)
+	}
+
+	// The new declaration: its name, parameters, and the uses of the
+	// selected parameters.
+	edits = append(edits, diff.Edit{Start: offset(decl.Name.Pos()), End: offset(decl.Name.Pos()), New: delegateTag})
+	var (
+		groups   []string // the new parameters, grouped as in the declaration
+		selected = make(map[types.Object]string)
+		i        = 0
+	)
+	for _, field := range decl.Type.Params.List {
+		var names []string
+		flush := func() {
+			if len(names) > 0 {
+				groups = append(groups, strings.Join(names, ", ")+" "+text(field.Type))
+				names = nil
+			}
+		}
+		for _, id := range field.Names {
+			switch {
+			case i == ps.start:
+				flush()
+				groups = append(groups, opts+" "+ps.name)
+				fallthrough
+			case ps.start < i && i < ps.end:
+				selected[info.Defs[id]] = opts + "." + ps.fields[i-ps.start]
+			default:
+				names = append(names, id.Name)
+			}
+			i++
+		}
+		flush()
+	}
+	edits = append(edits, diff.Edit{
+		Start: offset(decl.Type.Params.Opening + 1),
+		End:   offset(decl.Type.Params.Closing),
+		New:   strings.Join(groups, ", "),
+	})
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			if field, ok := selected[info.Uses[id]]; ok {
+				edits = append(edits, diff.Edit{Start: offset(id.Pos()), End: offset(id.End()), New: field})
+			}
+		}
+		return true
+	})
+
+	// The wrapper.
+	{
+		var buf strings.Builder
+		buf.WriteString("\n\nfunc ")
+		var call string
+		if decl.Recv != nil {
+			field := decl.Recv.List[0]
+			var id *ast.Ident
+			if len(field.Names) > 0 {
+				id = field.Names[0]
+			}
+			recv := paramName(used, id, "r")
+			fmt.Fprintf(&buf, "(%s %s) ", recv, text(field.Type))
+			call = recv + "."
+		}
+		call += delegateTag + ps.fn.Name()
+		var (
+			params []string
+			args   []string
+			lit    strings.Builder
+		)
+		fmt.Fprintf(&lit, "%s{\n", ps.name)
+		i := 0
+		for id, field := range astutil.FlatFields(decl.Type.Params) {
+			name := paramName(used, id, "p")
+			params = append(params, name+" "+text(field.Type))
+			switch {
+			case ps.start <= i && i < ps.end:
+				fmt.Fprintf(&lit, "%s: %s,\n", ps.fields[i-ps.start], name)
+				if i == ps.end-1 {
+					lit.WriteString("}")
+					args = append(args, lit.String())
+				}
+			case is[*ast.Ellipsis](field.Type):
+				args = append(args, name+"...")
+			default:
+				args = append(args, name)
+			}
+			i++
+		}
+		fmt.Fprintf(&buf, "%s(%s)", ps.fn.Name(), strings.Join(params, ", "))
+		if decl.Type.Results != nil {
+			buf.WriteString(" " + text(decl.Type.Results))
+		}
+		call = fmt.Sprintf("%s(%s)", call, strings.Join(args, ", "))
+		if decl.Type.Results.NumFields() > 0 {
+			fmt.Fprintf(&buf, " {\n\treturn %s\n}\n", call)
+		} else {
+			fmt.Fprintf(&buf, " {\n\t%s\n}\n", call)
+		}
+		end := len(bytes.TrimRight(pgf.Src, "\n\t "))
+		edits = append(edits, diff.Edit{Start: end, End: end, New: buf.String()})
+	}
+	return edits, nil
+}
+
+// splitLiterals breaks the struct literals of the new struct type in
+// the file uri, which are all produced by the inliner, into lines, one
+// per field, as fillstruct does.
+func (ps *paramStruct) splitLiterals(uri protocol.DocumentURI, src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, uri.Path(), src, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, bug.Errorf("parsing %s: %v", uri, err)
+	}
+	var edits []diff.Edit
+	ast.Inspect(file, func(n ast.Node) bool {
+		lit, ok := n.(*ast.CompositeLit)
+		if !ok || lit.Type == nil {
+			return true
+		}
+		var name string
+		switch t := lit.Type.(type) {
+		case *ast.Ident:
+			name = t.Name
+		case *ast.SelectorExpr:
+			name = t.Sel.Name
+		}
+		if name != ps.name || len(lit.Elts) == 0 || safetoken.Line(fset.File(lit.Pos()), lit.Rbrace) != safetoken.Line(fset.File(lit.Pos()), lit.Lbrace) {
+			return true
+		}
+		start, end := int(lit.Lbrace-file.FileStart), int(lit.Rbrace-file.FileStart)
+		lineStart := bytes.LastIndexByte(src[:start], '\n') + 1
+		indent := src[lineStart : lineStart+len(src[lineStart:])-len(bytes.TrimLeft(src[lineStart:], "\t"))]
+		formatted, err := fillstruct.FormatLiteral(fset, file, lit, lit.Elts, indent)
+		if err != nil {
+			return true // leave it as is
+		}
+		edits = append(edits, diff.Edit{Start: start, End: end + 1, New: string(formatted)})
+		return false
+	})
+	return diff.ApplyBytes(src, edits)
+}
@@ -40,6 +40,7 @@ import (
 	"html/template"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 
 	"golang.org/x/tools/gopls/internal/cache"
@@ -246,19 +247,9 @@ type Web interface {
 	SrcURL(filename string, line, col8 int) protocol.URI
 }
 
-// PackageDocHTML formats the package documentation page.
-//
-// The posURL function returns a URL that when visited, has the side
-// effect of causing gopls to direct the client editor to navigate to
-// the specified file/line/column position, in UTF-8 coordinates.
-//
-// TODO(adonovan): this function could use some unit tests; we
-// shouldn't have to use integration tests to cover microdetails of
-// HTML rendering. (It is tempting to abstract this function so that
-// it depends only on FileSet/File/Types/TypeInfo/etc, but we should
-// bend the tests to the production interfaces, not the other way
-// around.)
-func PackageDocHTML(viewID string, pkg *cache.Package, web Web) ([]byte, error) {
+// newDocPackage returns the go/doc documentation of the exported
+// symbols of the package.
+func newDocPackage(pkg *cache.Package) *doc.Package {
 	// We can't use doc.NewFromFiles (even with doc.PreserveAST
 	// mode) as it calls ast.NewPackage which assumes that each
 	// ast.File has an ast.Scope and resolves identifiers to
@@ -323,6 +314,23 @@ func PackageDocHTML(viewID string, pkg *cache.Package, web Web) ([]byte, error)
 			return strings.Compare(x.Name, y.Name)
 		})
 	}
+	return docpkg
+}
+
+// PackageDocHTML formats the package documentation page.
+//
+// The posURL function returns a URL that when visited, has the side
+// effect of causing gopls to direct the client editor to navigate to
+// the specified file/line/column position, in UTF-8 coordinates.
+//
+// TODO(adonovan): this function could use some unit tests; we
+// shouldn't have to use integration tests to cover microdetails of
+// HTML rendering. (It is tempting to abstract this function so that
+// it depends only on FileSet/File/Types/TypeInfo/etc, but we should
+// bend the tests to the production interfaces, not the other way
+// around.)
+func PackageDocHTML(viewID string, pkg *cache.Package, web Web) ([]byte, error) {
+	docpkg := newDocPackage(pkg)
 
 	// docHTML renders the doc comment as Markdown.
 	// The fileNode is used to deduce the enclosing file
@@ -841,3 +849,204 @@ window.addEventListener('load', function() {
 
 	return buf.Bytes(), nil
 }
+
+// PackageDocMarkdown formats the documentation of the exported symbols
+// of a package as Markdown, for consumption by tools such as LLMs
+// rather than a browser.
+//
+// If fragment is empty, the result documents the whole package;
+// otherwise it documents only the symbol it identifies, which has the
+// form "Name" or "Type.Method", as in the URL fragments of the
+// PackageDocHTML page.
+//
+// The linkURL function returns the URL of the documentation of a
+// package and optional symbol fragment; it is used for doc links such
+// as [fmt.Println].
+func PackageDocMarkdown(pkg *cache.Package, fragment string, linkURL func(path PackagePath, fragment string) string) (string, error) {
+	docpkg := newDocPackage(pkg)
+	scope := pkg.Types().Scope()
+
+	// docMarkdown renders the doc comment as Markdown.
+	// The fileNode is used to deduce the enclosing file
+	// for the correct import mapping.
+	var docMarkdown func(fileNode ast.Node, comment string) string
+	{
+		printer := &comment.Printer{
+			HeadingLevel: 3,
+			DocLinkURL: func(link *comment.DocLink) string {
+				path := pkg.Metadata().PkgPath
+				if link.ImportPath != "" {
+					path = PackagePath(link.ImportPath)
+				}
+				fragment := link.Name
+				if link.Recv != "" {
+					fragment = link.Recv + "." + link.Name
+				}
+				return linkURL(path, fragment)
+			},
+		}
+		parse := newDocCommentParser(pkg)
+		docMarkdown = func(fileNode ast.Node, comment string) string {
+			return string(printer.Markdown(parse(fileNode, comment)))
+		}
+	}
+
+	var buf strings.Builder
+
+	// code emits a declaration as a Go code block.
+	code := func(decl ast.Node) {
+		buf.WriteString("```go\n")
+		if err := format.Node(&buf, pkg.FileSet(), decl); err != nil {
+			fmt.Fprintf(&buf, "formatting error: %v", err) // e.g. BadDecl?
+		}
+		buf.WriteString("\n```\n\n")
+	}
+
+	// heading emits a heading for obj, noting when it was added
+	// to the standard library.
+	heading := func(level int, text string, obj types.Object) {
+		fmt.Fprintf(&buf, "%s %s", strings.Repeat("#", level), text)
+		if sym := StdSymbolOf(obj); sym != nil && sym.Version != stdlib.Version(0) {
+			fmt.Fprintf(&buf, " (added in %v)", sym.Version)
+		}
+		buf.WriteString("\n\n")
+	}
+
+	// comment emits the doc comment (if any) of a declaration.
+	comment := func(decl ast.Node, text string) {
+		if text != "" {
+			buf.WriteString(docMarkdown(decl, text))
+			buf.WriteString("\n")
+		}
+	}
+
+	value := func(v *doc.Value) {
+		decl2 := *v.Decl // shallow copy
+		decl2.Doc = nil
+		code(&decl2)
+		comment(v.Decl, v.Doc)
+	}
+
+	fn := func(level int, docfn *doc.Func, recv *doc.Type) {
+		var obj types.Object
+		if recv != nil {
+			tname := scope.Lookup(recv.Name).(*types.TypeName)
+			obj, _, _ = types.LookupFieldOrMethod(tname.Type(), true, tname.Pkg(), docfn.Name)
+			heading(level, fmt.Sprintf("func (%s) %s", docfn.Orig, docfn.Name), obj)
+		} else {
+			obj = scope.Lookup(docfn.Name)
+			heading(level, "func "+docfn.Name, obj)
+		}
+		decl2 := *docfn.Decl // shallow copy
+		decl2.Doc = nil
+		decl2.Body = nil
+		code(&decl2)
+		comment(docfn.Decl, docfn.Doc)
+	}
+
+	typ := func(level int, doctype *doc.Type) {
+		heading(level, "type "+doctype.Name, scope.Lookup(doctype.Name))
+		decl2 := *doctype.Decl // shallow copy
+		decl2.Doc = nil
+		code(&decl2)
+		comment(doctype.Decl, doctype.Doc)
+		for _, v := range doctype.Consts {
+			value(v)
+		}
+		for _, v := range doctype.Vars {
+			value(v)
+		}
+		for _, docfn := range doctype.Funcs {
+			fn(level+1, docfn, nil)
+		}
+		for _, method := range doctype.Methods {
+			fn(level+1, method, doctype)
+		}
+	}
+
+	// package name and import path
+	fmt.Fprintf(&buf, "# Package %s\n\n", pkg.Types().Name())
+	code(&ast.GenDecl{
+		Tok: token.IMPORT,
+		Specs: []ast.Spec{&ast.ImportSpec{
+			Path: &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(pkg.Types().Path())},
+		}},
+	})
+
+	if fragment != "" {
+		// Document a single symbol.
+		hasName := func(v *doc.Value) bool { return slices.Contains(v.Names, fragment) }
+		hasFunc := func(fn *doc.Func) bool { return fn.Name == fragment }
+		if i := slices.IndexFunc(docpkg.Consts, hasName); i >= 0 {
+			value(docpkg.Consts[i])
+			return buf.String(), nil
+		}
+		if i := slices.IndexFunc(docpkg.Vars, hasName); i >= 0 {
+			value(docpkg.Vars[i])
+			return buf.String(), nil
+		}
+		if i := slices.IndexFunc(docpkg.Funcs, hasFunc); i >= 0 {
+			fn(2, docpkg.Funcs[i], nil)
+			return buf.String(), nil
+		}
+		for _, doctype := range docpkg.Types {
+			if doctype.Name == fragment {
+				typ(2, doctype)
+				return buf.String(), nil
+			}
+			if i := slices.IndexFunc(doctype.Consts, hasName); i >= 0 {
+				value(doctype.Consts[i])
+				return buf.String(), nil
+			}
+			if i := slices.IndexFunc(doctype.Vars, hasName); i >= 0 {
+				value(doctype.Vars[i])
+				return buf.String(), nil
+			}
+			if i := slices.IndexFunc(doctype.Funcs, hasFunc); i >= 0 {
+				fn(2, doctype.Funcs[i], nil)
+				return buf.String(), nil
+			}
+			for _, method := range doctype.Methods {
+				if doctype.Name+"."+method.Name == fragment {
+					fn(2, method, doctype)
+					return buf.String(), nil
+				}
+			}
+		}
+		return "", fmt.Errorf("no exported symbol %s in package %s", fragment, pkg.Types().Path())
+	}
+
+	// package doc
+	for _, f := range pkg.Syntax() {
+		if f.Doc != nil {
+			comment(f.Doc, docpkg.Doc)
+			break
+		}
+	}
+
+	if len(docpkg.Consts) > 0 {
+		buf.WriteString("## Constants\n\n")
+		for _, v := range docpkg.Consts {
+			value(v)
+		}
+	}
+	if len(docpkg.Vars) > 0 {
+		buf.WriteString("## Variables\n\n")
+		for _, v := range docpkg.Vars {
+			value(v)
+		}
+	}
+	if len(docpkg.Funcs) > 0 {
+		buf.WriteString("## Functions\n\n")
+		for _, docfn := range docpkg.Funcs {
+			fn(3, docfn, nil)
+		}
+	}
+	if len(docpkg.Types) > 0 {
+		buf.WriteString("## Types\n\n")
+		for _, doctype := range docpkg.Types {
+			typ(3, doctype)
+		}
+	}
+	return buf.String(), nil
+}
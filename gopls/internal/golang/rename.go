@@ -70,6 +70,7 @@ import (
 	"golang.org/x/tools/gopls/internal/cache/parsego"
 	"golang.org/x/tools/gopls/internal/file"
 	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/protocol/command"
 	"golang.org/x/tools/gopls/internal/util/bug"
 	"golang.org/x/tools/gopls/internal/util/cursorutil"
 	"golang.org/x/tools/gopls/internal/util/pathutil"
@@ -328,7 +329,7 @@ func renameFuncSignature(ctx context.Context, pkg *cache.Package, pgf *parsego.F
 		}
 	}
 
-	var newParams []int
+	var newParams []command.ChangeSignatureParam
 	for name, field := range astutil.FlatFields(newType.Params) {
 		if name == nil {
 			return nil, fmt.Errorf("need named fields")
@@ -340,7 +341,7 @@ func renameFuncSignature(ctx context.Context, pkg *cache.Package, pgf *parsego.F
 		if newType := types.ExprString(field.Type); newType != info.typ {
 			return nil, fmt.Errorf("changing types (%s to %s) not yet supported", info.typ, newType)
 		}
-		newParams = append(newParams, info.idx)
+		newParams = append(newParams, command.ChangeSignatureParam{OldIndex: info.idx})
 	}
 
 	funcRng, err := pgf.PosRange(ftyp.Func, ftyp.Func)
@@ -469,47 +470,7 @@ func Rename(ctx context.Context, snapshot *cache.Snapshot, f file.Handle, rng pr
 		}
 	}
 
-	// Convert edits to protocol form.
-	result := make(map[protocol.DocumentURI][]protocol.TextEdit)
-	for uri, edits := range editMap {
-		// Sort and de-duplicate edits.
-		//
-		// Overlapping edits may arise in local renamings (due
-		// to type switch implicits) and globals ones (due to
-		// processing multiple package variants).
-		//
-		// We assume renaming produces diffs that are all
-		// replacements (no adjacent insertions that might
-		// become reordered) and that are either identical or
-		// non-overlapping.
-		diff.SortEdits(edits)
-		edits = slices.Compact(edits)
-
-		// TODO(adonovan): the logic above handles repeat edits to the
-		// same file URI (e.g. as a member of package p and p_test) but
-		// is not sufficient to handle file-system level aliasing arising
-		// from symbolic or hard links. For that, we should use a
-		// robustio-FileID-keyed map.
-		// See https://go.dev/cl/457615 for example.
-		// This really occurs in practice, e.g. kubernetes has
-		// vendor/k8s.io/kubectl -> ../../staging/src/k8s.io/kubectl.
-		fh, err := snapshot.ReadFile(ctx, uri)
-		if err != nil {
-			return nil, err
-		}
-		data, err := fh.Content()
-		if err != nil {
-			return nil, err
-		}
-		m := protocol.NewMapper(uri, data)
-		textedits, err := protocol.EditsFromDiffEdits(m, edits)
-		if err != nil {
-			return nil, err
-		}
-		result[uri] = textedits
-	}
-
-	changes, err := editsToDocChanges(ctx, snapshot, result)
+	changes, err := renamingDocChanges(ctx, snapshot, editMap)
 	if err != nil {
 		return nil, err
 	}
@@ -560,6 +521,50 @@ func Rename(ctx context.Context, snapshot *cache.Snapshot, f file.Handle, rng pr
 	return changes, nil
 }
 
+// renamingDocChanges converts the renaming edits in editMap to
+// protocol form.
+func renamingDocChanges(ctx context.Context, snapshot *cache.Snapshot, editMap map[protocol.DocumentURI][]diff.Edit) ([]protocol.DocumentChange, error) {
+	result := make(map[protocol.DocumentURI][]protocol.TextEdit)
+	for uri, edits := range editMap {
+		// Sort and de-duplicate edits.
+		//
+		// Overlapping edits may arise in local renamings (due
+		// to type switch implicits) and globals ones (due to
+		// processing multiple package variants).
+		//
+		// We assume renaming produces diffs that are all
+		// replacements (no adjacent insertions that might
+		// become reordered) and that are either identical or
+		// non-overlapping.
+		diff.SortEdits(edits)
+		edits = slices.Compact(edits)
+
+		// TODO(adonovan): the logic above handles repeat edits to the
+		// same file URI (e.g. as a member of package p and p_test) but
+		// is not sufficient to handle file-system level aliasing arising
+		// from symbolic or hard links. For that, we should use a
+		// robustio-FileID-keyed map.
+		// See https://go.dev/cl/457615 for example.
+		// This really occurs in practice, e.g. kubernetes has
+		// vendor/k8s.io/kubectl -> ../../staging/src/k8s.io/kubectl.
+		fh, err := snapshot.ReadFile(ctx, uri)
+		if err != nil {
+			return nil, err
+		}
+		data, err := fh.Content()
+		if err != nil {
+			return nil, err
+		}
+		m := protocol.NewMapper(uri, data)
+		textedits, err := protocol.EditsFromDiffEdits(m, edits)
+		if err != nil {
+			return nil, err
+		}
+		result[uri] = textedits
+	}
+	return editsToDocChanges(ctx, snapshot, result)
+}
+
 // renameOrdinary renames an ordinary (non-package) name throughout the workspace.
 func renameOrdinary(ctx context.Context, snapshot *cache.Snapshot, uri protocol.DocumentURI, rng protocol.Range, newName string) (map[protocol.DocumentURI][]diff.Edit, error) {
 	// Type-check the referring package and locate the object(s).
@@ -1004,6 +1009,10 @@ func updateModFiles(ctx context.Context, s *cache.Snapshot, oldDir string, newPk
 				continue //not affected by the package renanming
 			}
 
+			if moveSubpackages && replacedDir != r.New.Path && pathutil.InDir(oldDir, modFileDir) {
+				continue // the go.mod file moves along with the replacement
+			}
+
 			affectedReplaces = append(affectedReplaces, r)
 		}
 
@@ -1138,6 +1147,106 @@ func updatePackageDeclsAndImports(ctx context.Context, s *cache.Snapshot, f file
 	return nil
 }
 
+// RenameFiles returns the edits required to keep the workspace
+// consistent when the client moves or renames the given files or
+// directories, as reported by a workspace/willRenameFiles request.
+//
+// For each renamed directory, it updates the import declarations of
+// every importer of a package in the directory tree, along with any
+// affected replace directives in go.mod files. If the last element of
+// the directory changes, the package clause of the package in the
+// directory itself is renamed too, provided that its name matched the
+// old directory name. Renamings of individual files require no edits.
+func RenameFiles(ctx context.Context, snapshot *cache.Snapshot, renames []protocol.FileRename) ([]protocol.DocumentChange, error) {
+	ctx, done := event.Start(ctx, "golang.RenameFiles")
+	defer done()
+
+	allMetadata, err := snapshot.AllMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	editMap := make(map[protocol.DocumentURI][]diff.Edit)
+	for _, r := range renames {
+		if err := renameDirectory(ctx, snapshot, allMetadata, r.OldURI.Path(), r.NewURI.Path(), editMap); err != nil {
+			return nil, err
+		}
+	}
+	return renamingDocChanges(ctx, snapshot, editMap)
+}
+
+// renameDirectory computes the edits to package clauses, imports, and
+// go.mod files required by moving the directory oldDir to newDir,
+// and adds them to renamingEdits.
+//
+// Replace directives are updated even if oldDir contains no packages
+// whose paths change, such as when it holds a nested module.
+func renameDirectory(ctx context.Context, s *cache.Snapshot, allMetadata []*metadata.Package, oldDir, newDir string, renamingEdits map[protocol.DocumentURI][]diff.Edit) error {
+	var (
+		oldBase = filepath.Base(oldDir)
+		newBase = filepath.Base(newDir)
+	)
+	for _, mp := range allMetadata {
+		if len(mp.CompiledGoFiles) == 0 || metadata.IsCommandLineArguments(mp.ID) {
+			continue
+		}
+		pkgDir := mp.CompiledGoFiles[0].DirPath()
+		if !pathutil.InDir(oldDir, pkgDir) {
+			continue // not affected by the directory renaming
+		}
+		if mp.Module == nil {
+			// This check will always fail under Bazel.
+			return fmt.Errorf("cannot move package: missing module information for package %q", mp.PkgPath)
+		}
+		if pathutil.InDir(oldDir, mp.Module.Dir) {
+			continue // the whole module moves, so its import paths are unchanged
+		}
+
+		// Compute the new package path from the package's new
+		// location relative to its module root.
+		rel, err := filepath.Rel(oldDir, pkgDir)
+		if err != nil {
+			return err
+		}
+		rel, err = filepath.Rel(mp.Module.Dir, filepath.Join(newDir, rel))
+		if err != nil {
+			return err
+		}
+		if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("cannot move package %q outside of module %q", mp.PkgPath, mp.Module.Path)
+		}
+		newPkgPath := PackagePath(mp.Module.Path)
+		if rel != "." {
+			newPkgPath += "/" + PackagePath(filepath.ToSlash(rel))
+		}
+
+		// If the package lives in the renamed directory itself (not
+		// a subdirectory), and its name follows the directory name,
+		// it follows the directory to its new name.
+		newName := mp.Name
+		if pkgDir == oldDir && newBase != oldBase && isValidIdentifier(newBase) {
+			switch mp.Name {
+			case PackageName(oldBase):
+				newName = PackageName(newBase)
+			case PackageName(oldBase + "_test"):
+				newName = PackageName(newBase + "_test")
+			}
+			if newName != mp.Name {
+				if err := renamePackageClause(ctx, mp, s, newName, renamingEdits); err != nil {
+					return err
+				}
+			}
+		}
+
+		if mp.IsIntermediateTestVariant() || strings.HasSuffix(string(mp.Name), "_test") {
+			continue // x_test packages have no importers
+		}
+		if err := renameImports(ctx, s, mp, ImportPath(newPkgPath), newName, renamingEdits); err != nil {
+			return err
+		}
+	}
+	return updateModFiles(ctx, s, oldDir, newDir, renamingEdits, true)
+}
+
 // renamePackageClause computes edits renaming the package clause of files in
 // the package described by the given metadata, to newName.
 //
@@ -7,6 +7,7 @@ package golang
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"go/token"
 	"slices"
@@ -119,6 +120,14 @@ func ResolveCommand(ctx context.Context, params *protocol.ExecuteCommandParams,
 		if err := resolveMoveDeclaration(options, params); err != nil {
 			return nil, err
 		}
+	case "gopls.extract_interface":
+		if err := resolveExtractInterface(options, params); err != nil {
+			return nil, err
+		}
+	case "gopls.change_signature":
+		if err := resolveChangeSignature(options, params); err != nil {
+			return nil, err
+		}
 	}
 	return params, nil
 }
@@ -344,6 +353,146 @@ func resolveMoveDeclaration(options settings.ClientOptions, param *protocol.Exec
 	return nil
 }
 
+var addParamForm = []protocol.FormField{
+	{
+		ID:          "param",
+		Description: `the new parameter, as a name and a type; e.g. "ctx context.Context"`,
+		Type:        protocol.FormFieldTypeString{Kind: protocol.FormFieldKindString},
+		Required:    true,
+	},
+	{
+		ID:          "argument",
+		Description: `the argument for the new parameter at existing calls, e.g. "context.TODO()"; if empty, the zero value`,
+		Type:        protocol.FormFieldTypeString{Kind: protocol.FormFieldKindString},
+		Required:    false,
+		Default:     "",
+	},
+}
+
+// resolveChangeSignature asks for the parameter added by a "change
+// signature" command, if it adds exactly one.
+func resolveChangeSignature(options settings.ClientOptions, param *protocol.ExecuteCommandParams) error {
+	var a0 command.ChangeSignatureArgs
+	if err := command.UnmarshalArgs(param.Arguments, &a0); err != nil {
+		return err
+	}
+	var added []string
+	for _, p := range a0.NewParams {
+		if p.NewField != "" {
+			added = append(added, p.NewField)
+		}
+	}
+	if len(added) != 1 || !supportsDialog(options, addParamForm) {
+		return nil
+	}
+
+	// First call, return the form, proposing the parameter of the command.
+	if len(param.FormAnswers) == 0 {
+		form := slices.Clone(addParamForm)
+		form[0].Default = added[0]
+		param.FormFields = form
+		return nil
+	}
+
+	if _, err := AddedParamAnswer(&param.InteractiveParams); err != nil {
+		form := slices.Clone(addParamForm)
+		form[0].Error = err.Error()
+		param.FormFields = form
+		return nil
+	}
+	param.FormFields = nil
+	return nil
+}
+
+// AddedParamAnswer returns the parameter described by the answers to the
+// form of a "change signature" command, in the form accepted by
+// [ChangeSignature].
+func AddedParamAnswer(params *protocol.InteractiveParams) (string, error) {
+	field, err := FormAnswer[string](params, "param")
+	if err != nil {
+		return "", err
+	}
+	field = strings.TrimSpace(field)
+	// The argument is optional.
+	if slices.ContainsFunc(params.FormAnswers, func(ans protocol.FormAnswer) bool { return ans.ID == "argument" }) {
+		arg, err := FormAnswer[string](params, "argument")
+		if err != nil {
+			return "", err
+		}
+		if arg = strings.TrimSpace(arg); arg != "" {
+			field += " = " + arg
+		}
+	}
+	if _, err := parseAddedParam(field); err != nil {
+		return "", err
+	}
+	return field, nil
+}
+
+var extractInterfaceForm = []protocol.FormField{
+	{
+		ID:          "name",
+		Description: "the name of the interface",
+		Type:        protocol.FormFieldTypeString{Kind: protocol.FormFieldKindString},
+		Required:    true,
+	},
+	{
+		ID:          "methods",
+		Description: `comma-separated list of the methods of the interface; e.g. "Read,Close"`,
+		Type:        protocol.FormFieldTypeString{Kind: protocol.FormFieldKindString},
+		Required:    true,
+	},
+	{
+		ID:          "package",
+		Description: "path of the package in which to declare the interface",
+		Type:        protocol.FormFieldTypeString{Kind: protocol.FormFieldKindString},
+		Required:    true,
+	},
+	{
+		ID:          "function",
+		Description: `function whose parameters of the type should accept the interface, e.g. "Process", "T.Method", or "example.com/pkg.Process"; if empty, none`,
+		Type:        protocol.FormFieldTypeString{Kind: protocol.FormFieldKindString},
+		Required:    false,
+		Default:     "",
+	},
+}
+
+// resolveExtractInterface asks for the name, methods, and package of
+// the interface declared by an "extract interface" command.
+func resolveExtractInterface(options settings.ClientOptions, param *protocol.ExecuteCommandParams) error {
+	var a0 command.ExtractInterfaceArgs
+	if err := command.UnmarshalArgs(param.Arguments, &a0); err != nil {
+		return err
+	}
+
+	// First call, return the form, proposing all the exported methods.
+	if len(param.FormAnswers) == 0 {
+		form := slices.Clone(extractInterfaceForm)
+		form[0].Default = a0.Name
+		form[1].Default = strings.Join(a0.Methods, ",")
+		form[2].Default = a0.Package
+		param.FormFields = form
+		return nil
+	}
+
+	if _, err := ExtractInterfaceAnswer(&param.InteractiveParams); err != nil {
+		var fieldErr *formFieldError
+		if !errors.As(err, &fieldErr) {
+			return err
+		}
+		form := slices.Clone(extractInterfaceForm)
+		for i := range form {
+			if form[i].ID == fieldErr.id {
+				form[i].Error = fieldErr.Error()
+			}
+		}
+		param.FormFields = form
+		return nil
+	}
+	param.FormFields = nil
+	return nil
+}
+
 // FormAnswer finds, validates, and returns the unique answer for id.
 //
 // It uses a linear scan since the number of answers is small (usually < 5).
@@ -63,6 +63,13 @@ type emitter = func(out *bytes.Buffer, qual types.Qualifier) error
 //
 // fset must provide the position of sym.
 func insertDeclsAfter(ctx context.Context, snapshot *cache.Snapshot, mp *metadata.Package, fset *token.FileSet, sym types.Object, emit emitter) (*token.FileSet, *analysis.SuggestedFix, error) {
+	return spliceDecls(ctx, snapshot, mp, fset, sym, false, emit)
+}
+
+// spliceDecls is like insertDeclsAfter, but if lineComment is set,
+// the new declarations also follow any comment on the last line of
+// the declaration of sym, so that such a comment stays with it.
+func spliceDecls(ctx context.Context, snapshot *cache.Snapshot, mp *metadata.Package, fset *token.FileSet, sym types.Object, lineComment bool, emit emitter) (*token.FileSet, *analysis.SuggestedFix, error) {
 	// Parse the file declaring the sym.
 	//
 	// Beware: declPGF is not necessarily covered by pkg.FileSet() or si.Fset.
@@ -168,6 +175,18 @@ func insertDeclsAfter(ctx context.Context, snapshot *cache.Snapshot, mp *metadat
 		}
 		if declEndOffset > symOffset {
 			insertOffset = declEndOffset
+			if lineComment {
+				for _, cg := range declPGF.File.Comments {
+					if cg.Pos() >= decl.End() {
+						if safetoken.Line(declPGF.Tok, cg.Pos()) == safetoken.Line(declPGF.Tok, decl.End()) {
+							if insertOffset, err = safetoken.Offset(declPGF.Tok, cg.End()); err != nil {
+								return nil, nil, bug.Errorf("internal error: finding comment offset: %v", err)
+							}
+						}
+						break
+					}
+				}
+			}
 			break
 		}
 	}
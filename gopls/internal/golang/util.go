@@ -6,6 +6,7 @@ package golang
 
 import (
 	"context"
+	"fmt"
 	"go/ast"
 	"go/printer"
 	"go/token"
@@ -376,3 +377,18 @@ func buildConstraintComment(file *ast.File) *ast.Comment {
 
 	return nil
 }
+
+// paramName returns the name of the parameter declared by id, or, if
+// it is unnamed or blank, a name derived from base that is not among
+// used, which it adds to used.
+func paramName(used map[string]bool, id *ast.Ident, base string) string {
+	if id != nil && id.Name != "_" {
+		return id.Name
+	}
+	name := base
+	for i := 0; used[name]; i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	used[name] = true
+	return name
+}
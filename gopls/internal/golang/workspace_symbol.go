@@ -7,6 +7,7 @@ package golang
 import (
 	"cmp"
 	"context"
+	"encoding/json"
 	"fmt"
 	"path/filepath"
 	"runtime"
@@ -51,6 +52,11 @@ type WorkspaceSymbolsOptions struct {
 	// package-qualified) used for both matching against the query and for
 	// the final presentation.
 	Style settings.SymbolStyle
+
+	// URIOnly causes the location of each symbol to specify only its
+	// file; ResolveWorkspaceSymbol computes the range. It requires a
+	// client that can resolve the location.range property lazily.
+	URIOnly bool
 }
 
 // SymbolFilter returns whether the symbol we want to keep.
@@ -73,7 +79,7 @@ type SymbolFilter func(sym symbols.Symbol) bool
 // with a different configured SymbolMatcher per View. Therefore we assume that
 // Session level configuration will define the SymbolMatcher to be used for the
 // WorkspaceSymbols method.
-func WorkspaceSymbols(ctx context.Context, snapshots []*cache.Snapshot, query string, opts WorkspaceSymbolsOptions) ([]protocol.SymbolInformation, error) {
+func WorkspaceSymbols(ctx context.Context, snapshots []*cache.Snapshot, query string, opts WorkspaceSymbolsOptions) ([]protocol.WorkspaceSymbol, error) {
 	ctx, done := event.Start(ctx, "golang.WorkspaceSymbols")
 	defer done()
 	if query == "" {
@@ -305,7 +311,7 @@ func (c comboMatcher) match(chunks []string) (int, float64) {
 // The search behavior (scoring, filtering, and formatting) is governed by
 // the provided options. See [WorkspaceSymbolsOptions] for details on how
 // matches are calculated and styled.
-func collectSymbols(ctx context.Context, snapshots []*cache.Snapshot, query string, opts WorkspaceSymbolsOptions) ([]protocol.SymbolInformation, error) {
+func collectSymbols(ctx context.Context, snapshots []*cache.Snapshot, query string, opts WorkspaceSymbolsOptions) ([]protocol.WorkspaceSymbol, error) {
 	// Extract symbols from all files.
 	var work []symbolFile
 	seen := make(map[protocol.DocumentURI]*metadata.Package) // only scan each file once
@@ -422,7 +428,7 @@ func collectSymbols(ctx context.Context, snapshots []*cache.Snapshot, query stri
 			store := new(symbolStore)
 			// Assign files to workers in round-robin fashion.
 			for j := i; j < len(work); j += nmatchers {
-				matchFile(store, symbolizer, matcher, opts.Filter, work[j])
+				matchFile(store, symbolizer, matcher, opts.Filter, opts.URIOnly, work[j])
 			}
 			results <- store
 		}(i)
@@ -450,9 +456,10 @@ type symbolFile struct {
 }
 
 // matchFile scans a symbol file and adds matching symbols to the store.
-func matchFile(store *symbolStore, symbolizer symbolizer, matcher matcherFunc, filter SymbolFilter, f symbolFile) {
+// If uriOnly is set, their locations specify only the file.
+func matchFile(store *symbolStore, symbolizer symbolizer, matcher matcherFunc, filter SymbolFilter, uriOnly bool, f symbolFile) {
 	space := make([]string, 0, 3)
-	for _, sym := range f.syms {
+	for i, sym := range f.syms {
 		// Check if the symbol should be filtered out.
 		if filter != nil && !filter(sym) {
 			continue
@@ -530,13 +537,20 @@ func matchFile(store *symbolStore, symbolizer symbolizer, matcher matcherFunc, f
 
 		si := &scoredSymbol{
 			score: score,
-			info: protocol.SymbolInformation{
-				Name:          strings.Join(symbolParts, ""),
-				Kind:          sym.Kind,
-				Location:      f.uri.Location(sym.Range),
-				ContainerName: string(f.mp.PkgPath),
+			info: protocol.WorkspaceSymbol{
+				BaseSymbolInformation: protocol.BaseSymbolInformation{
+					Name:          strings.Join(symbolParts, ""),
+					Kind:          sym.Kind,
+					ContainerName: string(f.mp.PkgPath),
+				},
 			},
 		}
+		if uriOnly {
+			si.info.Location.Value = protocol.LocationUriOnly{URI: f.uri}
+			si.info.Data = workspaceSymbolData{Name: sym.Name, Index: i}
+		} else {
+			si.info.Location.Value = f.uri.Location(sym.Range)
+		}
 		store.store(si)
 	}
 }
@@ -577,8 +591,8 @@ func (sc *symbolStore) tooLow(score float64) bool {
 	return score <= last.score
 }
 
-func (sc *symbolStore) results() []protocol.SymbolInformation {
-	var res []protocol.SymbolInformation
+func (sc *symbolStore) results() []protocol.WorkspaceSymbol {
+	var res []protocol.WorkspaceSymbol
 	for _, si := range sc.res {
 		if si == nil || si.score <= 0 {
 			return res
@@ -590,5 +604,73 @@ func (sc *symbolStore) results() []protocol.SymbolInformation {
 
 type scoredSymbol struct {
 	score float64
-	info  protocol.SymbolInformation
+	info  protocol.WorkspaceSymbol
+}
+
+// workspaceSymbolData is the data of a workspace symbol whose location
+// specifies only its file. It identifies the symbol among those of the
+// file, independent of how its name is qualified.
+type workspaceSymbolData struct {
+	Name  string `json:"name"`  // name within the package, such as "T.f"
+	Index int    `json:"index"` // index among the symbols of the file
+}
+
+// ResolveWorkspaceSymbol fills in the range of a workspace symbol
+// whose location specifies only a file, by finding the symbol
+// identified by its data among the symbols of that file. Symbols that
+// already have a range are returned unchanged.
+func ResolveWorkspaceSymbol(ctx context.Context, snapshot *cache.Snapshot, sym *protocol.WorkspaceSymbol) (*protocol.WorkspaceSymbol, error) {
+	ctx, done := event.Start(ctx, "golang.ResolveWorkspaceSymbol")
+	defer done()
+
+	// A URI-only location arrives as a Location with an empty range,
+	// as the generated decoder tries Location first. No Go
+	// declaration starts at the beginning of a file, so an empty range
+	// at the start of the file is never a resolved location.
+	var uri protocol.DocumentURI
+	switch loc := sym.Location.Value.(type) {
+	case protocol.LocationUriOnly:
+		uri = loc.URI
+	case protocol.Location:
+		if loc.Range != (protocol.Range{}) {
+			return sym, nil // already resolved
+		}
+		uri = loc.URI
+	default:
+		return sym, nil
+	}
+	var data workspaceSymbolData
+	if raw, err := json.Marshal(sym.Data); err != nil {
+		return nil, err
+	} else if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("invalid workspace symbol data: %v", err)
+	}
+	mps, err := snapshot.MetadataForFile(ctx, uri, true)
+	if err != nil {
+		return nil, err
+	}
+	var ids []cache.PackageID
+	for _, mp := range mps {
+		ids = append(ids, mp.ID)
+	}
+	pkgs, err := snapshot.Symbols(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+	for _, pkg := range pkgs {
+		for i := range pkg.Files {
+			if pkg.Files[i] != uri {
+				continue
+			}
+			syms := pkg.Symbols[i]
+			if 0 <= data.Index && data.Index < len(syms) {
+				if s := syms[data.Index]; s.Name == data.Name && s.Kind == sym.Kind {
+					resolved := *sym
+					resolved.Location = protocol.OrPLocation_workspace_symbol{Value: uri.Location(s.Range)}
+					return &resolved, nil
+				}
+			}
+		}
+	}
+	return nil, fmt.Errorf("no symbol %q in %s", data.Name, uri)
 }
@@ -0,0 +1,231 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/file"
+	"golang.org/x/tools/gopls/internal/golang"
+	"golang.org/x/tools/gopls/internal/protocol"
+)
+
+// maxCallHierarchyDepth is the maximum depth of the call trees reported
+// by the "go_call_hierarchy" tool.
+const maxCallHierarchyDepth = 5
+
+// callHierarchyParams defines the parameters for the "go_call_hierarchy"
+// tool.
+type callHierarchyParams struct {
+	File      string `json:"file" jsonschema:"the absolute path to the file containing the symbol"`
+	Symbol    string `json:"symbol" jsonschema:"the symbol or qualified symbol of a function or method"`
+	Direction string `json:"direction,omitempty" jsonschema:"'incoming' for callers, 'outgoing' for callees, or empty for both"`
+	Depth     int    `json:"depth,omitempty" jsonschema:"the depth of the call trees, from 1 (the default) to 5"`
+}
+
+type callHierarchyResult struct {
+	Function function `json:"function"`
+	Incoming []call   `json:"incoming" jsonschema:"the tree of incoming calls, in depth-first order"`
+	Outgoing []call   `json:"outgoing" jsonschema:"the tree of outgoing calls, in depth-first order"`
+}
+
+// A function is a function or method in a call hierarchy.
+type function struct {
+	Name     string   `json:"name" jsonschema:"the package-qualified name of the function or method"`
+	Location location `json:"location" jsonschema:"the location of the declaration of the function"`
+}
+
+// A call is a node of a call tree.
+type call struct {
+	Depth    int        `json:"depth" jsonschema:"the depth of the node in the tree, starting at 1 for direct callers or callees"`
+	Function function   `json:"function" jsonschema:"the caller, for incoming calls, or the callee, for outgoing calls"`
+	Calls    []location `json:"calls" jsonschema:"the locations of the calls"`
+	Repeated bool       `json:"repeated,omitempty" jsonschema:"whether the function appears earlier in the tree, where its calls are expanded"`
+}
+
+// callHierarchyHandler is the handler for the "go_call_hierarchy" tool.
+// It reports the trees of incoming and/or outgoing calls of the requested
+// function, to the requested depth, one call per line.
+func (h *handler) callHierarchyHandler(ctx context.Context, req *mcp.CallToolRequest, params callHierarchyParams) (*mcp.CallToolResult, *callHierarchyResult, error) {
+	countGoCallHierarchyMCP.Inc()
+	var incoming, outgoing bool
+	switch params.Direction {
+	case "":
+		incoming, outgoing = true, true
+	case "incoming":
+		incoming = true
+	case "outgoing":
+		outgoing = true
+	default:
+		return nil, nil, fmt.Errorf("invalid direction %q: want 'incoming', 'outgoing', or empty", params.Direction)
+	}
+	depth := params.Depth
+	if depth == 0 {
+		depth = 1
+	}
+	if depth < 1 || depth > maxCallHierarchyDepth {
+		return nil, nil, fmt.Errorf("invalid depth %d: must be between 1 and %d", depth, maxCallHierarchyDepth)
+	}
+
+	fh, snapshot, release, err := h.fileOf(ctx, params.File)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer release()
+
+	if snapshot.FileKind(fh) != file.Go {
+		return nil, nil, fmt.Errorf("can't provide a call hierarchy for non-Go files")
+	}
+
+	loc, err := symbolLocation(ctx, snapshot, fh.URI(), params.Symbol)
+	if err != nil {
+		return nil, nil, err
+	}
+	declFH, err := snapshot.ReadFile(ctx, loc.URI)
+	if err != nil {
+		return nil, nil, err
+	}
+	items, err := golang.PrepareCallHierarchy(ctx, snapshot, declFH, loc.Range)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %v", params.Symbol, err)
+	}
+	root := items[0]
+
+	var b strings.Builder
+	locator := newLocator(ctx, snapshot)
+	result := &callHierarchyResult{Function: newFunction(ctx, snapshot, locator, root)}
+	fmt.Fprintf(&b, "Call hierarchy of %s, declared at %s:%d\n",
+		result.Function.Name, filepath.ToSlash(root.URI.Path()), root.Range.Start.Line+1)
+	if incoming {
+		b.WriteString("\nIncoming calls (callers, and the lines of their calls):\n")
+		w := callTreeWriter{ctx: ctx, snapshot: snapshot, locator: locator, b: &b, seen: make(map[protocol.Location]bool)}
+		if err := w.incoming(root, 1, depth); err != nil {
+			return nil, nil, err
+		}
+		result.Incoming = w.calls
+	}
+	if outgoing {
+		b.WriteString("\nOutgoing calls (callees, and the lines of the calls to them):\n")
+		w := callTreeWriter{ctx: ctx, snapshot: snapshot, locator: locator, b: &b, seen: make(map[protocol.Location]bool)}
+		if err := w.outgoing(root, 1, depth); err != nil {
+			return nil, nil, err
+		}
+		result.Outgoing = w.calls
+	}
+	return textResult(b.String()), result, nil
+}
+
+// A callTreeWriter writes a tree of calls, one per line, indented by
+// level, and records it in calls. Each function is expanded at most once.
+type callTreeWriter struct {
+	ctx      context.Context
+	snapshot *cache.Snapshot
+	locator  *locator
+	b        *strings.Builder
+	seen     map[protocol.Location]bool // expanded functions
+	calls    []call                     // in depth-first order
+}
+
+// incoming writes the callers of item, and their callers recursively,
+// up to the given depth.
+func (w *callTreeWriter) incoming(item protocol.CallHierarchyItem, level, depth int) error {
+	fh, err := w.snapshot.ReadFile(w.ctx, item.URI)
+	if err != nil {
+		return err
+	}
+	w.seen[item.URI.Location(item.Range)] = true
+	calls, err := golang.IncomingCalls(w.ctx, w.snapshot, fh, item.SelectionRange)
+	if err != nil {
+		return err
+	}
+	if len(calls) == 0 && level == 1 {
+		w.b.WriteString("(none)\n")
+	}
+	for _, call := range calls {
+		expanded := w.line(level, call.From, call.From.URI, call.FromRanges)
+		if expanded && level < depth && call.From.Kind == protocol.Function {
+			if err := w.incoming(call.From, level+1, depth); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// outgoing writes the callees of item, and their callees recursively,
+// up to the given depth.
+func (w *callTreeWriter) outgoing(item protocol.CallHierarchyItem, level, depth int) error {
+	fh, err := w.snapshot.ReadFile(w.ctx, item.URI)
+	if err != nil {
+		return err
+	}
+	w.seen[item.URI.Location(item.Range)] = true
+	calls, err := golang.OutgoingCalls(w.ctx, w.snapshot, fh, item.SelectionRange.Start)
+	if err != nil {
+		return err
+	}
+	if len(calls) == 0 && level == 1 {
+		w.b.WriteString("(none)\n")
+	}
+	for _, call := range calls {
+		expanded := w.line(level, call.To, item.URI, call.FromRanges)
+		if expanded && level < depth {
+			if err := w.outgoing(call.To, level+1, depth); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// line writes the line for a call to or from item, whose call sites
+// are the given ranges of the specified file. It reports whether item
+// should be expanded, that is, whether it has not been seen before.
+func (w *callTreeWriter) line(level int, item protocol.CallHierarchyItem, uri protocol.DocumentURI, sites []protocol.Range) bool {
+	c := call{
+		Depth:    level,
+		Function: newFunction(w.ctx, w.snapshot, w.locator, item),
+		Calls:    make([]location, len(sites)),
+	}
+	lines := make([]string, len(sites))
+	for i, rng := range sites {
+		lines[i] = fmt.Sprint(rng.Start.Line + 1)
+		c.Calls[i] = w.locator.location(uri.Location(rng))
+	}
+	fmt.Fprintf(w.b, "%s- %s at %s:%s", strings.Repeat("  ", level-1), c.Function.Name, filepath.ToSlash(uri.Path()), strings.Join(lines, ","))
+	c.Repeated = w.seen[item.URI.Location(item.Range)]
+	w.calls = append(w.calls, c)
+	if c.Repeated {
+		w.b.WriteString(" (see above)\n")
+		return false
+	}
+	w.b.WriteString("\n")
+	return true
+}
+
+// newFunction returns the function described by a call hierarchy item.
+func newFunction(ctx context.Context, snapshot *cache.Snapshot, locator *locator, item protocol.CallHierarchyItem) function {
+	return function{
+		Name:     callHierarchyItemName(ctx, snapshot, item),
+		Location: locator.location(item.URI.Location(item.Range)),
+	}
+}
+
+// callHierarchyItemName returns the package-qualified name of the
+// function described by a call hierarchy item.
+//
+// (The package path in the item's Detail is not used, as for incoming
+// calls it is that of the callee.)
+func callHierarchyItemName(ctx context.Context, snapshot *cache.Snapshot, item protocol.CallHierarchyItem) string {
+	mp, err := snapshot.NarrowestMetadataForFile(ctx, item.URI)
+	if err != nil {
+		return item.Name
+	}
+	return string(mp.PkgPath) + "." + item.Name
+}
@@ -0,0 +1,252 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package mcp
+
+// This file defines the "go_check_patch" tool, which reports the
+// diagnostics that would result from applying a patch, without
+// applying it.
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/file"
+	"golang.org/x/tools/gopls/internal/golang"
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/internal/diff"
+)
+
+type checkPatchParams struct {
+	Patch string `json:"patch" jsonschema:"a unified diff of one or more files, whose paths are absolute, or relative to the root of the workspace folder containing them"`
+}
+
+type checkPatchResult struct {
+	Files       []string     `json:"files" jsonschema:"the absolute paths to the files changed by the patch, which have not been modified"`
+	Diagnostics []diagnostic `json:"diagnostics" jsonschema:"the diagnostics of the workspace after the patch"`
+}
+
+// A filePatch is the portion of a unified diff that applies to one file.
+type filePatch struct {
+	oldPath, newPath string // "/dev/null" for created or deleted files
+	diff             string
+}
+
+func (h *handler) checkPatchHandler(ctx context.Context, req *mcp.CallToolRequest, params checkPatchParams) (*mcp.CallToolResult, *checkPatchResult, error) {
+	countGoCheckPatchMCP.Inc()
+	patches := splitPatch(params.Patch)
+	if len(patches) == 0 {
+		return nil, nil, fmt.Errorf("the patch changes no files: it must be a unified diff, with ---/+++ file headers")
+	}
+
+	views := h.views()
+	if len(views) == 0 {
+		return nil, nil, fmt.Errorf("No active builds.")
+	}
+	// resolve returns the URI of a file of the patch. A relative path
+	// is resolved against the root of the view that has such a file,
+	// which must be unique unless there is only one view.
+	resolve := func(path string) (protocol.DocumentURI, error) {
+		if filepath.IsAbs(path) {
+			return protocol.URIFromPath(path), nil
+		}
+		if len(views) == 1 {
+			return protocol.URIFromPath(filepath.Join(views[0].Root().Path(), filepath.FromSlash(path))), nil
+		}
+		var uris []protocol.DocumentURI
+		for _, v := range views {
+			uri := protocol.URIFromPath(filepath.Join(v.Root().Path(), filepath.FromSlash(path)))
+			if slices.Contains(uris, uri) {
+				continue
+			}
+			snapshot, release, err := v.Snapshot()
+			if err != nil {
+				continue // view is shut down
+			}
+			fh, err := snapshot.ReadFile(ctx, uri)
+			release()
+			if err != nil {
+				return "", err
+			}
+			if _, err := fh.Content(); err == nil {
+				uris = append(uris, uri)
+			}
+		}
+		switch len(uris) {
+		case 0:
+			return "", fmt.Errorf("%s is not a file of any workspace root; use an absolute path", path)
+		case 1:
+			return uris[0], nil
+		default:
+			return "", fmt.Errorf("%s is ambiguous, as it may denote %s or %s; use an absolute path", path, uris[0].Path(), uris[1].Path())
+		}
+	}
+
+	// Compute the new content of each file.
+	var snapshot *cache.Snapshot
+	contents := make(map[protocol.DocumentURI][]byte)
+	for _, p := range patches {
+		if p.newPath == "/dev/null" {
+			return nil, nil, fmt.Errorf("the patch deletes %s, which is not supported", p.oldPath)
+		}
+		uri, err := resolve(p.newPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		if p.oldPath != "/dev/null" {
+			if oldURI, err := resolve(p.oldPath); err != nil {
+				return nil, nil, err
+			} else if oldURI != uri {
+				return nil, nil, fmt.Errorf("the patch renames %s to %s, which is not supported", p.oldPath, p.newPath)
+			}
+		}
+		if snapshot == nil {
+			var (
+				release func()
+				err     error
+			)
+			snapshot, release, err = h.session.SnapshotOf(ctx, uri)
+			if err != nil {
+				return nil, nil, err
+			}
+			defer release()
+		}
+		var before []byte
+		if p.oldPath != "/dev/null" {
+			fh, err := snapshot.ReadFile(ctx, uri)
+			if err != nil {
+				return nil, nil, err
+			}
+			if before, err = fh.Content(); err != nil {
+				return nil, nil, fmt.Errorf("reading %s: %v", uri.Path(), err)
+			}
+		}
+		if prev, ok := contents[uri]; ok {
+			before = prev // a file may be patched more than once
+		}
+		after, err := diff.ApplyUnified(p.diff, string(before))
+		if err != nil {
+			return nil, nil, fmt.Errorf("applying the patch to %s: %v", uri.Path(), err)
+		}
+		contents[uri] = []byte(after)
+	}
+
+	patched, release, err := snapshot.WithOverlays(ctx, contents)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer release()
+
+	diagnostics, err := patched.PackageDiagnostics(ctx, slices.Collect(patched.WorkspacePackages().Keys())...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("diagnostics failed: %v", err)
+	}
+	for uri := range contents {
+		if fh, err := patched.ReadFile(ctx, uri); err != nil || patched.FileKind(fh) != file.Go {
+			continue
+		}
+		// Get more specific diagnostics for the patched files.
+		fileDiagnostics, err := golang.DiagnoseFile(ctx, patched, uri)
+		if err != nil {
+			return nil, nil, fmt.Errorf("diagnostics failed: %v", err)
+		}
+		diagnostics[uri] = fileDiagnostics
+	}
+
+	var (
+		b      strings.Builder
+		result checkPatchResult
+		loc    = newLocator(ctx, patched)
+	)
+	b.WriteString("The patch applies to the following files, which have not been modified:\n")
+	for _, uri := range slices.Sorted(maps.Keys(contents)) {
+		fmt.Fprintf(&b, "\t%s\n", uri.Path())
+		result.Files = append(result.Files, uri.Path())
+	}
+	b.WriteString("\n")
+	n := b.Len()
+	for _, uri := range slices.Sorted(maps.Keys(diagnostics)) {
+		diags := diagnostics[uri]
+		if len(diags) > 0 {
+			fmt.Fprintf(&b, "After the patch, file `%s` would have the following diagnostics:\n", uri.Path())
+			summary, err := summarizeDiagnostics(ctx, patched, loc, &b, diags, nil)
+			if err != nil {
+				return nil, nil, err
+			}
+			result.Diagnostics = append(result.Diagnostics, summary...)
+			fmt.Fprintln(&b)
+		}
+	}
+	if b.Len() == n {
+		b.WriteString("After the patch, there would be no diagnostics.\n")
+	}
+	return textResult(b.String()), &result, nil
+}
+
+// splitPatch splits a unified diff into the portions that apply to
+// each file, in order. Lines outside of the hunks, such as the "diff
+// --git" and "index" lines of git diffs, are ignored, as are the a/ and
+// b/ prefixes of git file names.
+func splitPatch(patch string) []filePatch {
+	var (
+		patches []filePatch
+		p       *filePatch
+		buf     strings.Builder
+	)
+	flush := func() {
+		if p != nil {
+			p.diff = buf.String()
+			patches = append(patches, *p)
+			buf.Reset()
+		}
+	}
+	lines := strings.SplitAfter(patch, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if strings.HasPrefix(line, "--- ") && i+1 < len(lines) && strings.HasPrefix(lines[i+1], "+++ ") {
+			flush()
+			p = &filePatch{
+				oldPath: patchPath(line[len("--- "):]),
+				newPath: patchPath(lines[i+1][len("+++ "):]),
+			}
+			// Remove the a/ and b/ prefixes of git diffs.
+			oldPath, oldOK := strings.CutPrefix(p.oldPath, "a/")
+			newPath, newOK := strings.CutPrefix(p.newPath, "b/")
+			if (oldOK || p.oldPath == "/dev/null") && (newOK || p.newPath == "/dev/null") && (oldOK || newOK) {
+				if oldOK {
+					p.oldPath = oldPath
+				}
+				if newOK {
+					p.newPath = newPath
+				}
+			}
+			i++
+			continue
+		}
+		if p == nil {
+			continue // preamble
+		}
+		switch {
+		case strings.TrimRight(line, "\r\n") == "",
+			strings.HasPrefix(line, "@@"),
+			strings.ContainsAny(line[:1], " +-\\"):
+			buf.WriteString(line)
+		}
+	}
+	flush()
+	return patches
+}
+
+// patchPath returns the path of a ---/+++ file header, without any
+// timestamp.
+func patchPath(header string) string {
+	header = strings.TrimRight(header, "\r\n")
+	path, _, _ := strings.Cut(header, "\t")
+	return strings.TrimSpace(path)
+}
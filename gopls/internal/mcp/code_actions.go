@@ -0,0 +1,246 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package mcp
+
+// This file defines the "go_code_actions" and "go_code_action_edits"
+// tools, which list the code actions (quick fixes and refactorings)
+// available at a selection, and compute the edits of one of them.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/file"
+	"golang.org/x/tools/gopls/internal/golang"
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/protocol/command"
+)
+
+// selectionParams defines the selection of a code action request.
+type selectionParams struct {
+	File        string `json:"file" jsonschema:"the absolute path to the Go file"`
+	StartLine   int    `json:"start_line" jsonschema:"the 1-based line number of the start of the selection"`
+	StartColumn int    `json:"start_column,omitempty" jsonschema:"the 1-based byte column of the start of the selection (default 1)"`
+	EndLine     int    `json:"end_line,omitempty" jsonschema:"the 1-based line number of the end of the selection (default: the start of the selection)"`
+	EndColumn   int    `json:"end_column,omitempty" jsonschema:"the 1-based byte column of the end of the selection (default 1)"`
+}
+
+type codeActionsParams struct {
+	selectionParams
+	Kind string `json:"kind,omitempty" jsonschema:"if set, report only code actions of this kind, such as 'quickfix' or 'refactor.extract'"`
+}
+
+type codeActionEditsParams struct {
+	selectionParams
+	Title string `json:"title" jsonschema:"the title of the code action, as reported by go_code_actions"`
+}
+
+type codeActionsResult struct {
+	Actions []codeAction `json:"actions"`
+}
+
+type codeAction struct {
+	Title string `json:"title" jsonschema:"the title of the code action, which identifies it in go_code_action_edits"`
+	Kind  string `json:"kind" jsonschema:"the kind of the code action, such as 'quickfix' or 'refactor.extract.function'"`
+}
+
+func (h *handler) codeActionsHandler(ctx context.Context, req *mcp.CallToolRequest, params codeActionsParams) (*mcp.CallToolResult, *codeActionsResult, error) {
+	countGoCodeActionsMCP.Inc()
+	fh, snapshot, release, err := h.fileOf(ctx, params.File)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer release()
+
+	actions, err := h.codeActions(ctx, snapshot, fh, params.selectionParams, params.Kind)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(actions) == 0 {
+		return textResult("No code actions are available at the selection.\n"), nil, nil
+	}
+	var (
+		b      strings.Builder
+		result codeActionsResult
+	)
+	fmt.Fprintf(&b, "Found %d code action(s) at the selection:\n", len(actions))
+	for _, action := range actions {
+		fmt.Fprintf(&b, "- %q (%s)\n", action.Title, action.Kind)
+		result.Actions = append(result.Actions, codeAction{Title: action.Title, Kind: string(action.Kind)})
+	}
+	return textResult(b.String()), &result, nil
+}
+
+func (h *handler) codeActionEditsHandler(ctx context.Context, req *mcp.CallToolRequest, params codeActionEditsParams) (*mcp.CallToolResult, *editsResult, error) {
+	countGoCodeActionEditsMCP.Inc()
+	fh, snapshot, release, err := h.fileOf(ctx, params.File)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer release()
+
+	actions, err := h.codeActions(ctx, snapshot, fh, params.selectionParams, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	var action *protocol.CodeAction
+	var titles []string
+	for i := range actions {
+		if actions[i].Title == params.Title {
+			action = &actions[i]
+			break
+		}
+		titles = append(titles, fmt.Sprintf("%q", actions[i].Title))
+	}
+	if action == nil {
+		if len(titles) == 0 {
+			return nil, nil, fmt.Errorf("no code actions are available at the selection")
+		}
+		return nil, nil, fmt.Errorf("no code action %q at the selection; available actions are %s", params.Title, strings.Join(titles, ", "))
+	}
+	edit, err := h.codeActionEdit(ctx, action)
+	if err != nil {
+		return nil, nil, err
+	}
+	diffs, err := unifiedDiffs(ctx, snapshot, edit.DocumentChanges)
+	if err != nil {
+		return nil, nil, err
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "The following changes are necessary to apply the code action %q:\n", action.Title)
+	for _, d := range diffs {
+		b.WriteString(d.Diff)
+		b.WriteString("\n")
+	}
+	return textResult(b.String()), &editsResult{Changes: diffs}, nil
+}
+
+// codeActions returns the code actions of the specified kind (or all
+// kinds, if empty) that are available at the selection and that edit
+// the workspace. Actions that only run commands, such as running tests
+// or opening a web page, are omitted.
+func (h *handler) codeActions(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle, sel selectionParams, kind string) ([]protocol.CodeAction, error) {
+	if snapshot.FileKind(fh) != file.Go {
+		return nil, fmt.Errorf("can't provide code actions for non-Go files")
+	}
+	content, err := fh.Content()
+	if err != nil {
+		return nil, err
+	}
+	rng, err := selectionRange(protocol.NewMapper(fh.URI(), content), sel)
+	if err != nil {
+		return nil, err
+	}
+
+	// Quick fixes apply to the diagnostics that intersect the selection.
+	diagnostics, err := golang.DiagnoseFile(ctx, snapshot, fh.URI())
+	if err != nil {
+		return nil, err
+	}
+	var pdiags []protocol.Diagnostic
+	for _, d := range diagnostics {
+		if protocol.Intersect(d.Range, rng) {
+			pdiags = append(pdiags, cache.ToProtocolDiagnostics(d)...)
+		}
+	}
+	var only []protocol.CodeActionKind
+	if kind != "" {
+		only = []protocol.CodeActionKind{protocol.CodeActionKind(kind)}
+	}
+	actions, err := h.lspServer.CodeAction(ctx, &protocol.CodeActionParams{
+		TextDocument: protocol.TextDocumentIdentifier{URI: fh.URI()},
+		Range:        rng,
+		Context: protocol.CodeActionContext{
+			Only:        only,
+			Diagnostics: pdiags,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []protocol.CodeAction
+	seen := make(map[string]bool) // titles must identify actions
+	for _, action := range actions {
+		if action.Data != nil {
+			// The client supports codeAction/resolve, so the
+			// edit or command of the action must be resolved.
+			resolved, err := h.lspServer.ResolveCodeAction(ctx, &action)
+			if err != nil {
+				return nil, err
+			}
+			action = *resolved
+		}
+		editing := action.Edit != nil ||
+			action.Command != nil && slices.Contains(command.ResolveEditsCommands, command.Command(action.Command.Command))
+		if editing && !seen[action.Title] {
+			seen[action.Title] = true
+			result = append(result, action)
+		}
+	}
+	return result, nil
+}
+
+// codeActionEdit returns the workspace edit of a code action, executing
+// its command, if any, to compute the edit without applying it.
+func (h *handler) codeActionEdit(ctx context.Context, action *protocol.CodeAction) (*protocol.WorkspaceEdit, error) {
+	if action.Edit != nil {
+		return action.Edit, nil
+	}
+	cmd := action.Command
+	if cmd == nil || !slices.Contains(command.ResolveEditsCommands, command.Command(cmd.Command)) || len(cmd.Arguments) == 0 {
+		return nil, fmt.Errorf("code action %q does not edit the workspace", action.Title)
+	}
+
+	// Ask the command to return its edits rather than apply them
+	// using workspace/applyEdit, which an MCP client can't handle.
+	var args map[string]any
+	if err := json.Unmarshal(cmd.Arguments[0], &args); err != nil {
+		return nil, err
+	}
+	args["ResolveEdits"] = true
+	arg, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+	res, err := h.lspServer.ExecuteCommand(ctx, &protocol.ExecuteCommandParams{
+		Command:   cmd.Command,
+		Arguments: append([]json.RawMessage{arg}, cmd.Arguments[1:]...),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("computing the edits of code action %q: %v", action.Title, err)
+	}
+	edit, ok := res.(*protocol.WorkspaceEdit)
+	if !ok || edit == nil {
+		return nil, fmt.Errorf("code action %q returned no edits", action.Title)
+	}
+	return edit, nil
+}
+
+// selectionRange returns the protocol range of the selection.
+func selectionRange(m *protocol.Mapper, sel selectionParams) (protocol.Range, error) {
+	if sel.StartColumn == 0 {
+		sel.StartColumn = 1
+	}
+	if sel.EndLine == 0 {
+		sel.EndLine, sel.EndColumn = sel.StartLine, sel.StartColumn
+	} else if sel.EndColumn == 0 {
+		sel.EndColumn = 1
+	}
+	start, err := m.LineCol8Position(sel.StartLine, sel.StartColumn)
+	if err != nil {
+		return protocol.Range{}, fmt.Errorf("invalid start of selection in %s: %v", filepath.Base(sel.File), err)
+	}
+	end, err := m.LineCol8Position(sel.EndLine, sel.EndColumn)
+	if err != nil {
+		return protocol.Range{}, fmt.Errorf("invalid end of selection in %s: %v", filepath.Base(sel.File), err)
+	}
+	return protocol.Range{Start: start, End: end}, nil
+}
@@ -31,7 +31,12 @@ type ContextParams struct {
 	File string `json:"file" jsonschema:"the absolute path to the file"`
 }
 
-func (h *handler) contextHandler(ctx context.Context, req *mcp.CallToolRequest, params ContextParams) (*mcp.CallToolResult, any, error) {
+type contextResult struct {
+	Package packageInfo   `json:"package" jsonschema:"the package of the file"`
+	Imports []packageInfo `json:"imports" jsonschema:"the packages imported by the file whose APIs are summarized, which exclude the standard library"`
+}
+
+func (h *handler) contextHandler(ctx context.Context, req *mcp.CallToolRequest, params ContextParams) (*mcp.CallToolResult, *contextResult, error) {
 	countGoContextMCP.Inc()
 	fh, snapshot, release, err := h.fileOf(ctx, params.File)
 	if err != nil {
@@ -49,6 +54,7 @@ func (h *handler) contextHandler(ctx context.Context, req *mcp.CallToolRequest,
 		return nil, nil, err
 	}
 
+	structured := &contextResult{Package: newPackageInfo(pkg.Metadata())}
 	var result strings.Builder
 
 	fmt.Fprintf(&result, "Current package %q (package %s):\n\n", pkg.Metadata().PkgPath, pkg.Metadata().Name)
@@ -129,12 +135,13 @@ func (h *handler) contextHandler(ctx context.Context, req *mcp.CallToolRequest,
 				}
 				if summary := summarizePackage(ctx, snapshot, md); summary != "" {
 					result.WriteString(summary)
+					structured.Imports = append(structured.Imports, newPackageInfo(md))
 				}
 			}
 		}
 	}
 
-	return textResult(result.String()), nil, nil
+	return textResult(result.String()), structured, nil
 }
 
 func summarizePackage(ctx context.Context, snapshot *cache.Snapshot, md *metadata.Package) string {
@@ -9,16 +9,28 @@ import "golang.org/x/telemetry/counter"
 // Proposed counters for evaluating usage of Go MCP Server tools. These counters
 // increment when a user utilizes a specific Go MCP tool.
 var (
-	countGoContextMCP          = counter.New("gopls/mcp-tool:go_context")
-	countGoDiagnosticsMCP      = counter.New("gopls/mcp-tool:go_diagnostics")
-	countGoFileContextMCP      = counter.New("gopls/mcp-tool:go_file_context")
-	countGoFileDiagnosticsMCP  = counter.New("gopls/mcp-tool:go_file_diagnostics")
-	countGoFileMetadataMCP     = counter.New("gopls/mcp-tool:go_file_metadata")
-	countGoPackageAPIMCP       = counter.New("gopls/mcp-tool:go_package_api")
-	countGoReferencesMCP       = counter.New("gopls/mcp-tool:go_references")
-	countGoRenameSymbolMCP     = counter.New("gopls/mcp-tool:go_rename_symbol")
-	countGoSearchMCP           = counter.New("gopls/mcp-tool:go_search")
-	countGoSymbolReferencesMCP = counter.New("gopls/mcp-tool:go_symbol_references")
-	countGoWorkspaceMCP        = counter.New("gopls/mcp-tool:go_workspace")
-	countGoVulncheckMCP        = counter.New("gopls/mcp-tool:go_vulncheck")
+	countGoCallHierarchyMCP        = counter.New("gopls/mcp-tool:go_call_hierarchy")
+	countGoCheckPatchMCP           = counter.New("gopls/mcp-tool:go_check_patch")
+	countGoCodeActionsMCP          = counter.New("gopls/mcp-tool:go_code_actions")
+	countGoCodeActionEditsMCP      = counter.New("gopls/mcp-tool:go_code_action_edits")
+	countGoContextMCP              = counter.New("gopls/mcp-tool:go_context")
+	countGoDefinitionMCP           = counter.New("gopls/mcp-tool:go_definition")
+	countGoDocResourceMCP          = counter.New("gopls/mcp-resource:go-doc")
+	countGoDiagnosticsMCP          = counter.New("gopls/mcp-tool:go_diagnostics")
+	countGoExplainPackagePromptMCP = counter.New("gopls/mcp-prompt:go_explain_package")
+	countGoFileContextMCP          = counter.New("gopls/mcp-tool:go_file_context")
+	countGoFileDiagnosticsMCP      = counter.New("gopls/mcp-tool:go_file_diagnostics")
+	countGoFileMetadataMCP         = counter.New("gopls/mcp-tool:go_file_metadata")
+	countGoFixTestsPromptMCP       = counter.New("gopls/mcp-prompt:go_fix_tests")
+	countGoImplementationsMCP      = counter.New("gopls/mcp-tool:go_implementations")
+	countGoModuleGraphMCP          = counter.New("gopls/mcp-tool:go_module_graph")
+	countGoPackageAPIMCP           = counter.New("gopls/mcp-tool:go_package_api")
+	countGoReferencesMCP           = counter.New("gopls/mcp-tool:go_references")
+	countGoRenameSymbolMCP         = counter.New("gopls/mcp-tool:go_rename_symbol")
+	countGoSearchMCP               = counter.New("gopls/mcp-tool:go_search")
+	countGoSymbolReferencesMCP     = counter.New("gopls/mcp-tool:go_symbol_references")
+	countGoTestMCP                 = counter.New("gopls/mcp-tool:go_test")
+	countGoTypeHierarchyMCP        = counter.New("gopls/mcp-tool:go_type_hierarchy")
+	countGoWorkspaceMCP            = counter.New("gopls/mcp-tool:go_workspace")
+	countGoVulncheckMCP            = counter.New("gopls/mcp-tool:go_vulncheck")
 )
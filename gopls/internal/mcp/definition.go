@@ -0,0 +1,109 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package mcp
+
+// This file defines the "go_definition" tool, which describes the
+// declaration of a symbol.
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/gopls/internal/file"
+	"golang.org/x/tools/gopls/internal/golang"
+	"golang.org/x/tools/gopls/internal/protocol"
+)
+
+// definitionParams defines the parameters for the "go_definition" tool.
+type definitionParams struct {
+	File   string `json:"file" jsonschema:"the absolute path to the Go file containing the symbol"`
+	Symbol string `json:"symbol,omitempty" jsonschema:"the symbol or qualified symbol; if empty, the symbol is identified by line and column"`
+	Line   int    `json:"line,omitempty" jsonschema:"the 1-based line number of an identifier, if symbol is empty"`
+	Column int    `json:"column,omitempty" jsonschema:"the 1-based byte column of an identifier, if symbol is empty"`
+}
+
+type definitionResult struct {
+	Location  location `json:"location" jsonschema:"the location of the declaration of the symbol"`
+	Signature string   `json:"signature" jsonschema:"the declaration of the symbol, as Go source; for a type, followed by its promoted fields and methods"`
+	Doc       string   `json:"doc,omitempty" jsonschema:"the documentation of the symbol, in doc comment syntax"`
+}
+
+// definitionHandler is the handler for the "go_definition" tool.
+// It reports the location, signature, and documentation of the symbol
+// identified by name, or by its position.
+func (h *handler) definitionHandler(ctx context.Context, req *mcp.CallToolRequest, params definitionParams) (*mcp.CallToolResult, *definitionResult, error) {
+	countGoDefinitionMCP.Inc()
+	if (params.Symbol == "") == (params.Line == 0) {
+		return nil, nil, fmt.Errorf("exactly one of symbol or line must be set")
+	}
+	fh, snapshot, release, err := h.fileOf(ctx, params.File)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer release()
+
+	if snapshot.FileKind(fh) != file.Go {
+		return nil, nil, fmt.Errorf("can't provide definitions for non-Go files")
+	}
+
+	// Find the declaration, and the identifier on which to hover.
+	var (
+		decl  protocol.Location
+		hovFH = fh
+		hovAt protocol.Range
+		name  = params.Symbol
+	)
+	if params.Symbol != "" {
+		decl, err = symbolLocation(ctx, snapshot, fh.URI(), params.Symbol)
+		if err != nil {
+			return nil, nil, err
+		}
+		hovFH, err = snapshot.ReadFile(ctx, decl.URI)
+		if err != nil {
+			return nil, nil, err
+		}
+		hovAt = decl.Range
+	} else {
+		content, err := fh.Content()
+		if err != nil {
+			return nil, nil, err
+		}
+		sel := selectionParams{File: params.File, StartLine: params.Line, StartColumn: params.Column}
+		hovAt, err = selectionRange(protocol.NewMapper(fh.URI(), content), sel)
+		if err != nil {
+			return nil, nil, err
+		}
+		name = fmt.Sprintf("the symbol at %s:%d:%d", filepath.Base(params.File), params.Line, max(params.Column, 1))
+		locs, err := golang.Definition(ctx, snapshot, fh, hovAt)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(locs) == 0 {
+			return nil, nil, fmt.Errorf("no symbol at %s:%d:%d", filepath.Base(params.File), params.Line, max(params.Column, 1))
+		}
+		decl = locs[0]
+	}
+	signature, doc, err := golang.HoverDoc(ctx, snapshot, hovFH, hovAt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := &definitionResult{
+		Location:  newLocator(ctx, snapshot).location(decl),
+		Signature: signature,
+		Doc:       doc,
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "The declaration of %s is at %s\n", name, formatLocationLine(ctx, snapshot, decl))
+	if signature != "" {
+		fmt.Fprintf(&b, "\n```go\n%s\n```\n", strings.Trim(signature, "\n"))
+	}
+	if doc != "" {
+		fmt.Fprintf(&b, "\n%s\n", strings.Trim(doc, "\n"))
+	}
+	return textResult(b.String()), result, nil
+}
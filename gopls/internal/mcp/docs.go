@@ -0,0 +1,154 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package mcp
+
+// This file defines the "go-doc" resources, which render the
+// documentation of packages and their symbols, and the prompts
+// for common tasks.
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/gopls/internal/cache/metadata"
+	"golang.org/x/tools/gopls/internal/golang"
+)
+
+// goDocScheme is the URI scheme of package documentation resources,
+// which have the form go-doc://importpath or go-doc://importpath#Symbol.
+const goDocScheme = "go-doc"
+
+// goDocURI returns the URI of the documentation of a package and
+// optional symbol, which has the form "Name" or "Type.Method".
+func goDocURI(path metadata.PackagePath, symbol string) string {
+	uri := goDocScheme + "://" + string(path)
+	if symbol != "" {
+		uri += "#" + symbol
+	}
+	return uri
+}
+
+// addResources adds the resources of the gopls MCP server.
+func addResources(mcpServer *mcp.Server, h handler) {
+	mcpServer.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "go-doc",
+		Title:       "Go package documentation",
+		URITemplate: goDocScheme + "://{+importpath}{#symbol}",
+		MIMEType:    "text/markdown",
+		Description: `The documentation of the exported declarations of a Go package in the
+workspace or its dependencies, in the manner of 'go doc', for example
+go-doc://net/http. If a symbol is specified, the documentation is restricted to
+that constant, variable, function, type, or method, for example
+go-doc://net/http#Client.Do.`,
+	}, h.goDocHandler)
+}
+
+func (h *handler) goDocHandler(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	countGoDocResourceMCP.Inc()
+	uri := req.Params.URI
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != goDocScheme {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+	// The authority is the first segment of the import path.
+	text, err := h.packageDoc(ctx, metadata.PackagePath(u.Host+u.Path), u.Fragment)
+	if err != nil {
+		return nil, err
+	}
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{{URI: uri, MIMEType: "text/markdown", Text: text}},
+	}, nil
+}
+
+// packageDoc returns the documentation of a package and optional
+// symbol as Markdown, or a "resource not found" error if there is no
+// such package.
+func (h *handler) packageDoc(ctx context.Context, path metadata.PackagePath, symbol string) (string, error) {
+	snapshot, release, err := h.snapshot()
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	md, err := snapshot.LoadMetadataGraph(ctx)
+	if err != nil {
+		return "", err
+	}
+	mps := md.ForPackagePath[path]
+	if len(mps) == 0 {
+		return "", mcp.ResourceNotFoundError(goDocURI(path, symbol))
+	}
+	pkgs, err := snapshot.TypeCheck(ctx, mps[0].ID) // first is best
+	if err != nil {
+		return "", err
+	}
+	return golang.PackageDocMarkdown(pkgs[0], symbol, goDocURI)
+}
+
+// addPrompts adds the prompts of the gopls MCP server.
+func addPrompts(mcpServer *mcp.Server, h handler) {
+	mcpServer.AddPrompt(&mcp.Prompt{
+		Name:        "go_explain_package",
+		Title:       "Explain a Go package",
+		Description: "Explains the purpose and API of a Go package, using its documentation.",
+		Arguments: []*mcp.PromptArgument{{
+			Name:        "package",
+			Description: "the import path of the package, such as net/http",
+			Required:    true,
+		}},
+	}, h.explainPackagePrompt)
+	mcpServer.AddPrompt(&mcp.Prompt{
+		Name:        "go_fix_tests",
+		Title:       "Fix failing Go tests",
+		Description: "Runs the tests of a Go package and fixes the failures.",
+		Arguments: []*mcp.PromptArgument{{
+			Name:        "package",
+			Description: "the import path or pattern of the packages to test, such as example.com/foo/...",
+			Required:    true,
+		}},
+	}, h.fixTestsPrompt)
+}
+
+func (h *handler) explainPackagePrompt(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	countGoExplainPackagePromptMCP.Inc()
+	path := req.Params.Arguments["package"]
+	if path == "" {
+		return nil, fmt.Errorf("missing package argument")
+	}
+	// Embed the documentation, so that it need not be fetched by a tool call.
+	uri := goDocURI(metadata.PackagePath(path), "")
+	text, err := h.packageDoc(ctx, metadata.PackagePath(path), "")
+	if err != nil {
+		return nil, err
+	}
+	doc := &mcp.ResourceContents{URI: uri, MIMEType: "text/markdown", Text: text}
+	return &mcp.GetPromptResult{
+		Description: "Explain package " + path,
+		Messages: []*mcp.PromptMessage{
+			{Role: "user", Content: &mcp.EmbeddedResource{Resource: doc}},
+			{Role: "user", Content: &mcp.TextContent{Text: fmt.Sprintf(`Using the documentation above, explain the purpose of the Go package %s, and summarize its most important types and functions and how they are used together.
+Use go_search and go_symbol_references to find examples of their use in the workspace.`, path)}},
+		},
+	}, nil
+}
+
+func (h *handler) fixTestsPrompt(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	countGoFixTestsPromptMCP.Inc()
+	pattern := req.Params.Arguments["package"]
+	if pattern == "" {
+		return nil, fmt.Errorf("missing package argument")
+	}
+	return &mcp.GetPromptResult{
+		Description: "Fix the failing tests of " + pattern,
+		Messages: []*mcp.PromptMessage{
+			{Role: "user", Content: &mcp.TextContent{Text: fmt.Sprintf(`Run the tests of the Go packages %s using the go_test tool, and fix the failures:
+1. For each failed test, read the test and the code under test, and decide whether the code or the test is wrong.
+2. Make the fix, then call go_diagnostics on the edited files, and fix any errors.
+3. Run the failed tests again using go_test, and repeat until they pass.
+Don't change the expectations of a test unless you are sure that the test is wrong.`, pattern)}},
+		},
+	}, nil
+}
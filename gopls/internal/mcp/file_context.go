@@ -9,18 +9,33 @@ import (
 	"fmt"
 	"go/ast"
 	"go/types"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"golang.org/x/tools/gopls/internal/golang"
 	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/util/moremaps"
 )
 
 type fileContextParams struct {
 	File string `json:"file" jsonschema:"the absolute path to the file"`
 }
 
-func (h *handler) fileContextHandler(ctx context.Context, req *mcp.CallToolRequest, params fileContextParams) (*mcp.CallToolResult, any, error) {
+type fileContextResult struct {
+	Package string        `json:"package" jsonschema:"the path of the package of the file"`
+	Uses    []fileSymbols `json:"uses" jsonschema:"the symbols used by the file that are declared in other files"`
+}
+
+// A fileSymbols is a set of symbols declared in a file.
+type fileSymbols struct {
+	File    string   `json:"file" jsonschema:"the absolute path to the file that declares the symbols"`
+	Package string   `json:"package" jsonschema:"the path of the package of the file"`
+	Symbols []string `json:"symbols" jsonschema:"the names of the symbols"`
+}
+
+func (h *handler) fileContextHandler(ctx context.Context, req *mcp.CallToolRequest, params fileContextParams) (*mcp.CallToolResult, *fileContextResult, error) {
 	countGoFileContextMCP.Inc()
 	fh, snapshot, release, err := h.fileOf(ctx, params.File)
 	if err != nil {
@@ -68,11 +83,12 @@ func (h *handler) fileContextHandler(ctx context.Context, req *mcp.CallToolReque
 		addObj(info.Defs[id])
 	}
 
+	structured := &fileContextResult{Package: string(pkg.Metadata().PkgPath)}
 	var result strings.Builder
 	fmt.Fprintf(&result, "File `%s` is in package %q.\n", params.File, pkg.Metadata().PkgPath)
 	fmt.Fprintf(&result, "Below is a summary of the APIs it uses from other files.\n")
 	fmt.Fprintf(&result, "To read the full API of any package, use go_package_api.\n")
-	for uri, decls := range otherFiles {
+	for uri, decls := range moremaps.Sorted(otherFiles) {
 		pkgPath := "UNKNOWN"
 		md, err := snapshot.NarrowestMetadataForFile(ctx, uri)
 		if err != nil {
@@ -82,6 +98,11 @@ func (h *handler) fileContextHandler(ctx context.Context, req *mcp.CallToolReque
 		} else {
 			pkgPath = string(md.PkgPath)
 		}
+		structured.Uses = append(structured.Uses, fileSymbols{
+			File:    uri.Path(),
+			Package: pkgPath,
+			Symbols: slices.Sorted(maps.Keys(decls)),
+		})
 		fmt.Fprintf(&result, "Referenced declarations from %s (package %q):\n", uri.Path(), pkgPath)
 		result.WriteString("```go\n")
 		if err := writeFileSummary(ctx, snapshot, uri, &result, false, decls); err != nil {
@@ -90,5 +111,5 @@ func (h *handler) fileContextHandler(ctx context.Context, req *mcp.CallToolReque
 		result.WriteString("```\n\n")
 	}
 
-	return textResult(result.String()), nil, nil
+	return textResult(result.String()), structured, nil
 }
@@ -27,7 +27,7 @@ type diagnosticsParams struct {
 	File string `json:"file" jsonschema:"the absolute path to the file to diagnose"`
 }
 
-func (h *handler) fileDiagnosticsHandler(ctx context.Context, req *mcp.CallToolRequest, params diagnosticsParams) (*mcp.CallToolResult, any, error) {
+func (h *handler) fileDiagnosticsHandler(ctx context.Context, req *mcp.CallToolRequest, params diagnosticsParams) (*mcp.CallToolResult, *diagnosticsResult, error) {
 	countGoFileDiagnosticsMCP.Inc()
 	fh, snapshot, release, err := h.fileOf(ctx, params.File)
 	if err != nil {
@@ -45,11 +45,12 @@ func (h *handler) fileDiagnosticsHandler(ctx context.Context, req *mcp.CallToolR
 		return textResult("No diagnostics"), nil, nil
 	}
 
-	if err := summarizeDiagnostics(ctx, snapshot, &builder, diagnostics, fixes); err != nil {
+	diags, err := summarizeDiagnostics(ctx, snapshot, newLocator(ctx, snapshot), &builder, diagnostics, fixes)
+	if err != nil {
 		return nil, nil, err
 	}
 
-	return textResult(builder.String()), nil, nil
+	return textResult(builder.String()), &diagnosticsResult{Diagnostics: diags}, nil
 }
 
 // diagnoseFile diagnoses a single file, including go/analysis and quick fixes.
@@ -102,68 +103,101 @@ func (h *handler) diagnoseFile(ctx context.Context, snapshot *cache.Snapshot, ur
 	return diagnostics, fixes, nil
 }
 
-func summarizeDiagnostics(ctx context.Context, snapshot *cache.Snapshot, w *strings.Builder, diagnostics []*cache.Diagnostic, fixes map[*cache.Diagnostic]*protocol.CodeAction) error {
+// summarizeDiagnostics writes a summary of the diagnostics and their
+// fixes to w, and returns their structured form.
+func summarizeDiagnostics(ctx context.Context, snapshot *cache.Snapshot, loc *locator, w *strings.Builder, diagnostics []*cache.Diagnostic, fixes map[*cache.Diagnostic]*protocol.CodeAction) ([]diagnostic, error) {
+	var diags []diagnostic
 	for _, d := range diagnostics {
 		fmt.Fprintf(w, "%d:%d-%d:%d: [%s] %s\n", d.Range.Start.Line, d.Range.Start.Character, d.Range.End.Line, d.Range.End.Character, d.Severity, d.Message)
 
+		diag := diagnostic{
+			Location: loc.location(d.URI.Location(d.Range)),
+			Severity: severityName(d.Severity),
+			Code:     d.Code,
+			Source:   string(d.Source),
+			Message:  d.Message,
+		}
 		fix, ok := fixes[d]
 		if ok && fix.Edit != nil {
-			w.WriteString("Fix:\n")
-			if err := writeUnifiedDiff(ctx, snapshot, w, fix.Edit.DocumentChanges); err != nil {
-				return err
+			var diff strings.Builder
+			if err := writeUnifiedDiff(ctx, snapshot, &diff, fix.Edit.DocumentChanges); err != nil {
+				return nil, err
 			}
+			diag.Fix = diff.String()
+			w.WriteString("Fix:\n")
+			w.WriteString(diag.Fix)
 			w.WriteString("\n")
 		}
+		diags = append(diags, diag)
 	}
-	return nil
+	return diags, nil
 }
 
 // writeUnifiedDiff converts each [protocol.DocumentChange] into a separate
 // unified diff and write to the input writer.
+func writeUnifiedDiff(ctx context.Context, snapshot *cache.Snapshot, w *strings.Builder, changes []protocol.DocumentChange) error {
+	diffs, err := unifiedDiffs(ctx, snapshot, changes)
+	if err != nil {
+		return err
+	}
+	for _, d := range diffs {
+		w.WriteString(d.Diff)
+		w.WriteString("\n")
+	}
+	return nil
+}
+
+// unifiedDiffs converts each [protocol.DocumentChange] into a separate
+// unified diff.
 //
 // All returned diffs use forward slash ('/') as the file path separator for
 // consistency, regardless of the original system's separator.
 // Multiple changes targeting the same file are not consolidated.
 //
 // TODO(hxjiang): consolidate diffs to the same file.
-func writeUnifiedDiff(ctx context.Context, snapshot *cache.Snapshot, w *strings.Builder, changes []protocol.DocumentChange) error {
+func unifiedDiffs(ctx context.Context, snapshot *cache.Snapshot, changes []protocol.DocumentChange) ([]fileDiff, error) {
+	var diffs []fileDiff
 	for _, change := range changes {
 		// The before-and-after states for the file change.
 		var (
+			uri                    protocol.DocumentURI // of the changed file
 			oldFile, newFile       string
 			oldContent, newContent string
 		)
 		switch {
 		case change.CreateFile != nil:
+			uri = change.CreateFile.URI
 			oldFile, newFile = "/dev/null", filepath.ToSlash(change.CreateFile.URI.Path())
 			oldContent, newContent = "", ""
 		case change.DeleteFile != nil:
 			fh, err := snapshot.ReadFile(ctx, change.DeleteFile.URI)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			content, err := fh.Content()
 			if err != nil {
-				return err
+				return nil, err
 			}
+			uri = change.DeleteFile.URI
 			oldFile, newFile = filepath.ToSlash(change.DeleteFile.URI.Path()), "/dev/null"
 			oldContent, newContent = string(content), ""
 		case change.RenameFile != nil:
 			fh, err := snapshot.ReadFile(ctx, change.RenameFile.OldURI)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			content, err := fh.Content()
 			if err != nil {
-				return err
+				return nil, err
 			}
+			uri = change.RenameFile.OldURI
 			oldFile = filepath.ToSlash(change.RenameFile.OldURI.Path())
 			newFile = filepath.ToSlash(change.RenameFile.NewURI.Path())
 			oldContent, newContent = string(content), string(content)
 		case change.TextDocumentEdit != nil:
 			fh, err := snapshot.ReadFile(ctx, change.TextDocumentEdit.TextDocument.URI)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			// Assumes gopls never return AnnotatedTextEdit.
@@ -177,7 +211,7 @@ func writeUnifiedDiff(ctx context.Context, snapshot *cache.Snapshot, w *strings.
 
 			content, err := fh.Content()
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			var newSrc bytes.Buffer
@@ -188,7 +222,7 @@ func writeUnifiedDiff(ctx context.Context, snapshot *cache.Snapshot, w *strings.
 				for _, edit := range sorted {
 					l, r, err := mapper.RangeOffsets(edit.Range)
 					if err != nil {
-						return err
+						return nil, err
 					}
 
 					newSrc.Write(content[start:l])
@@ -199,13 +233,16 @@ func writeUnifiedDiff(ctx context.Context, snapshot *cache.Snapshot, w *strings.
 				newSrc.Write(content[start:])
 			}
 
+			uri = fh.URI()
 			oldFile, newFile = filepath.ToSlash(fh.URI().Path()), filepath.ToSlash(fh.URI().Path())
 			oldContent, newContent = string(content), newSrc.String()
 		default:
 			continue // this shouldn't happen
 		}
-		w.WriteString(diff.Unified(oldFile, newFile, oldContent, newContent))
-		w.WriteString("\n")
+		diffs = append(diffs, fileDiff{
+			File: uri.Path(),
+			Diff: diff.Unified(oldFile, newFile, oldContent, newContent),
+		})
 	}
-	return nil
+	return diffs, nil
 }
@@ -16,7 +16,11 @@ type fileMetadataParams struct {
 	File string `json:"file" jsonschema:"the absolute path to the file to describe"`
 }
 
-func (h *handler) fileMetadataHandler(ctx context.Context, req *mcp.CallToolRequest, params fileMetadataParams) (*mcp.CallToolResult, any, error) {
+type fileMetadataResult struct {
+	Package packageInfo `json:"package" jsonschema:"the package of the file"`
+}
+
+func (h *handler) fileMetadataHandler(ctx context.Context, req *mcp.CallToolRequest, params fileMetadataParams) (*mcp.CallToolResult, *fileMetadataResult, error) {
 	countGoFileMetadataMCP.Inc()
 	fh, snapshot, release, err := h.fileOf(ctx, params.File)
 	if err != nil {
@@ -37,5 +41,5 @@ func (h *handler) fileMetadataHandler(ctx context.Context, req *mcp.CallToolRequ
 	for _, f := range md.CompiledGoFiles {
 		addf("\t%s\n", f.Path())
 	}
-	return textResult(b.String()), nil, nil
+	return textResult(b.String()), &fileMetadataResult{Package: newPackageInfo(md)}, nil
 }
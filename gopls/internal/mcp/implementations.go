@@ -0,0 +1,87 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/file"
+	"golang.org/x/tools/gopls/internal/golang"
+	"golang.org/x/tools/gopls/internal/protocol"
+)
+
+// implementationsParams defines the parameters for the "go_implementations"
+// tool.
+type implementationsParams struct {
+	File   string `json:"file" jsonschema:"the absolute path to the file containing the symbol"`
+	Symbol string `json:"symbol" jsonschema:"the symbol or qualified symbol of a type or method"`
+}
+
+type implementationsResult struct {
+	Implementations []location `json:"implementations" jsonschema:"the locations of the declarations of the implementations"`
+}
+
+// implementationsHandler is the handler for the "go_implementations" tool.
+// It reports the types that implement (or are implemented by) the requested
+// type, or the corresponding methods of the requested method.
+func (h *handler) implementationsHandler(ctx context.Context, req *mcp.CallToolRequest, params implementationsParams) (*mcp.CallToolResult, *implementationsResult, error) {
+	countGoImplementationsMCP.Inc()
+	fh, snapshot, release, err := h.fileOf(ctx, params.File)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer release()
+
+	if snapshot.FileKind(fh) != file.Go {
+		return nil, nil, fmt.Errorf("can't provide implementations for non-Go files")
+	}
+
+	loc, err := symbolLocation(ctx, snapshot, fh.URI(), params.Symbol)
+	if err != nil {
+		return nil, nil, err
+	}
+	declFH, err := snapshot.ReadFile(ctx, loc.URI)
+	if err != nil {
+		return nil, nil, err
+	}
+	impls, err := golang.Implementation(ctx, snapshot, declFH, loc.Range)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(impls) == 0 {
+		return textResult(fmt.Sprintf("No implementations of %s found.\n", params.Symbol)), nil, nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Found %d implementation(s) of %s:\n", len(impls), params.Symbol)
+	for _, loc := range impls {
+		fmt.Fprintf(&b, "- %s\n", formatLocationLine(ctx, snapshot, loc))
+	}
+	result := &implementationsResult{Implementations: newLocator(ctx, snapshot).locations(impls)}
+	return textResult(b.String()), result, nil
+}
+
+// formatLocationLine formats a location compactly as file:line, followed
+// by the (trimmed) content of the line, if available.
+func formatLocationLine(ctx context.Context, snapshot *cache.Snapshot, loc protocol.Location) string {
+	s := fmt.Sprintf("%s:%d", filepath.ToSlash(loc.URI.Path()), loc.Range.Start.Line+1)
+	fh, err := snapshot.ReadFile(ctx, loc.URI)
+	if err != nil {
+		return s
+	}
+	content, err := fh.Content()
+	if err != nil {
+		return s
+	}
+	lines := strings.Split(string(content), "\n")
+	if int(loc.Range.Start.Line) >= len(lines) {
+		return s
+	}
+	return fmt.Sprintf("%s: `%s`", s, strings.TrimSpace(lines[loc.Range.Start.Line]))
+}
@@ -13,6 +13,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"slices"
 	"strings"
 	"sync"
 
@@ -30,9 +31,65 @@ import (
 var Instructions string
 
 // A handler implements various MCP tools for an LSP session.
+//
+// Several MCP sessions may share an LSP session, each with its own
+// handler.
 type handler struct {
 	session   *cache.Session
 	lspServer protocol.Server
+	roots     *roots // the workspace roots of the MCP client
+}
+
+// roots holds the workspace roots reported by an MCP client, which
+// select the views on which its workspace queries operate.
+type roots struct {
+	mu   sync.Mutex
+	dirs []protocol.DocumentURI
+}
+
+func (r *roots) get() []protocol.DocumentURI {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.dirs
+}
+
+func (r *roots) set(res *mcp.ListRootsResult) {
+	var dirs []protocol.DocumentURI
+	for _, root := range res.Roots {
+		// Unlike LSP, MCP does not check URI validity during unmarshaling.
+		if dir, err := protocol.ParseDocumentURI(root.URI); err == nil {
+			dirs = append(dirs, dir.Clean())
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.dirs = dirs
+}
+
+// A Transport is an HTTP transport of the MCP protocol.
+// Its value must be one of the constants below; callers that accept
+// a transport from the user must check it.
+type Transport string
+
+const (
+	// SSE is the HTTP with server-sent events transport of the
+	// 2024-11-05 version of the MCP specification.
+	SSE Transport = "sse"
+	// Streamable is the streamable HTTP transport, which replaces SSE
+	// in later versions of the MCP specification.
+	Streamable Transport = "streamable"
+)
+
+// newHTTPHandler returns an HTTP handler for the transport, which
+// calls getServer to create the server of each new MCP session.
+func (t Transport) newHTTPHandler(getServer func(*http.Request) *mcp.Server) http.Handler {
+	switch t {
+	case SSE:
+		return mcp.NewSSEHandler(getServer, nil)
+	case Streamable:
+		return mcp.NewStreamableHTTPHandler(getServer, nil)
+	}
+	panic(fmt.Sprintf("unknown MCP transport %q", t))
 }
 
 // Sessions is the interface used to access gopls sessions.
@@ -42,7 +99,9 @@ type Sessions interface {
 	SetSessionExitFunc(func(string))
 }
 
-// Serve starts an MCP server serving at the input address.
+// Serve starts an MCP server serving at the input address, using the
+// specified HTTP transport. Each MCP session has its own server, and
+// concurrent MCP sessions may share an LSP session.
 //
 // The server receives LSP session events on the specified channel, which the
 // caller is responsible for closing. The server runs until the context is
@@ -52,7 +111,7 @@ type Sessions interface {
 // subsequently whenever the MCP client signals a change to the workspace roots.
 // It is passed the list roots result returned by the MCP client, or an error
 // if the roots could not be retrieved. rootsHandler may be called concurrently.
-func Serve(ctx context.Context, address string, sessions Sessions, isDaemon bool, rootsHandler func(*mcp.ListRootsResult, error)) error {
+func Serve(ctx context.Context, address string, transport Transport, sessions Sessions, isDaemon bool, rootsHandler func(*mcp.ListRootsResult, error)) error {
 	if strings.HasPrefix(address, ":") {
 		return fmt.Errorf("address %s implicitly binds all network interfaces; please use an explicit host such as 0.0.0.0 (all interfaces) or localhost (safer)", address)
 	}
@@ -70,7 +129,7 @@ func Serve(ctx context.Context, address string, sessions Sessions, isDaemon bool
 	defer log.Printf("Gopls MCP server: exiting")
 
 	svr := http.Server{
-		Handler: HTTPHandler(sessions, isDaemon, rootsHandler),
+		Handler: HTTPHandler(sessions, transport, isDaemon, rootsHandler),
 		BaseContext: func(net.Listener) context.Context {
 			return ctx
 		},
@@ -99,10 +158,12 @@ func StartStdIO(ctx context.Context, session *cache.Session, server protocol.Ser
 
 }
 
-func HTTPHandler(sessions Sessions, isDaemon bool, rootsHandler func(*mcp.ListRootsResult, error)) http.Handler {
+// HTTPHandler returns the handler of an HTTP server that serves MCP
+// over the specified transport.
+func HTTPHandler(sessions Sessions, transport Transport, isDaemon bool, rootsHandler func(*mcp.ListRootsResult, error)) http.Handler {
 	var (
-		mu          sync.Mutex                         // lock for mcpHandlers.
-		mcpHandlers = make(map[string]*mcp.SSEHandler) // map from lsp session ids to MCP sse handlers.
+		mu          sync.Mutex                      // lock for mcpHandlers.
+		mcpHandlers = make(map[string]http.Handler) // map from lsp session ids to MCP http handlers.
 	)
 	mux := http.NewServeMux()
 
@@ -116,9 +177,9 @@ func HTTPHandler(sessions Sessions, isDaemon bool, rootsHandler func(*mcp.ListRo
 			handler, ok := mcpHandlers[sessionID]
 			if !ok {
 				if s, svr := sessions.Session(sessionID); s != nil {
-					handler = mcp.NewSSEHandler(func(request *http.Request) *mcp.Server {
+					handler = transport.newHTTPHandler(func(request *http.Request) *mcp.Server {
 						return NewServer(s, svr, rootsHandler)
-					}, nil)
+					})
 					mcpHandlers[sessionID] = handler
 				}
 			}
@@ -139,9 +200,9 @@ func HTTPHandler(sessions Sessions, isDaemon bool, rootsHandler func(*mcp.ListRo
 			_, handler, ok := moremaps.Arbitrary(mcpHandlers)
 			if !ok {
 				s, svr := sessions.FirstSession()
-				handler = mcp.NewSSEHandler(func(request *http.Request) *mcp.Server {
+				handler = transport.newHTTPHandler(func(request *http.Request) *mcp.Server {
 					return NewServer(s, svr, rootsHandler)
-				}, nil)
+				})
 				mcpHandlers[s.ID()] = handler
 			}
 			mu.Unlock()
@@ -168,6 +229,7 @@ func NewServer(session *cache.Session, lspServer protocol.Server, rootsHandler f
 	h := handler{
 		session:   session,
 		lspServer: lspServer,
+		roots:     new(roots),
 	}
 	opts := &mcp.ServerOptions{}
 	mcpServer := mcp.NewServer(&mcp.Implementation{Name: "gopls", Version: "v1.0.0"}, opts)
@@ -178,8 +240,17 @@ func NewServer(session *cache.Session, lspServer protocol.Server, rootsHandler f
 		"go_diagnostics",
 		"go_rename_symbol",
 		"go_symbol_references",
+		"go_definition",
+		"go_implementations",
+		"go_type_hierarchy",
+		"go_call_hierarchy",
+		"go_code_actions",
+		"go_code_action_edits",
+		"go_check_patch",
 		"go_search",
 		"go_file_context",
+		"go_test",
+		"go_module_graph",
 		"go_vulncheck"}
 	disabledTools := append(defaultTools,
 		// The fileMetadata tool is redundant with fileContext.
@@ -219,60 +290,148 @@ func NewServer(session *cache.Session, lspServer protocol.Server, rootsHandler f
 	for _, tool := range tools {
 		addToolByName(mcpServer, h, tool)
 	}
-
-	// Subscribe to the roots change.
-	if rootsHandler != nil {
-		mcpServer.AddReceivingMiddleware(func(next mcp.MethodHandler) mcp.MethodHandler {
-			return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
-				result, err := next(ctx, method, req)
-
-				// Read roots list after initialized once and every time roots
-				// list changes and pass them to handler.
-				//
-				// See MCP spec:
-				//
-				//  The server SHOULD NOT send requests other than pings
-				//  and logging before receiving the initialized notification.
-				//
-				// https://modelcontextprotocol.info/specification/2024-11-05/basic/lifecycle/#initialization
-				if method == "notifications/initialized" || method == "notifications/roots/list_changed" {
-					go func() {
-						var session *mcp.ServerSession
-						for s := range mcpServer.Sessions() {
-							if s.ID() == req.GetSession().ID() {
-								session = s
-								break
-							}
-						}
-
-						if session == nil { // session terminated.
-							return
+	addResources(mcpServer, h)
+	addPrompts(mcpServer, h)
+
+	// Subscribe to the roots change. The roots of the client select the
+	// views of its workspace queries.
+	mcpServer.AddReceivingMiddleware(func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			result, err := next(ctx, method, req)
+
+			// Read roots list after initialized once and every time roots
+			// list changes and pass them to handler.
+			//
+			// See MCP spec:
+			//
+			//  The server SHOULD NOT send requests other than pings
+			//  and logging before receiving the initialized notification.
+			//
+			// https://modelcontextprotocol.info/specification/2024-11-05/basic/lifecycle/#initialization
+			if method == "notifications/initialized" || method == "notifications/roots/list_changed" {
+				go func() {
+					var session *mcp.ServerSession
+					for s := range mcpServer.Sessions() {
+						if s.ID() == req.GetSession().ID() {
+							session = s
+							break
 						}
-
-						if session.InitializeParams().Capabilities.RootsV2 == nil {
-							return // client does not support roots
-						}
-
-						roots, err := session.ListRoots(context.Background(), &mcp.ListRootsParams{})
-						rootsHandler(roots, err)
-					}()
-				}
-
-				return result, err
+					}
+
+					if session == nil { // session terminated.
+						return
+					}
+
+					if session.InitializeParams().Capabilities.RootsV2 == nil {
+						return // client does not support roots
+					}
+
+					res, err := session.ListRoots(context.Background(), &mcp.ListRootsParams{})
+					if err == nil {
+						h.roots.set(res)
+					}
+					if rootsHandler != nil {
+						rootsHandler(res, err)
+					}
+				}()
 			}
-		})
-	}
+
+			return result, err
+		}
+	})
 
 	return mcpServer
 }
 
 func addToolByName(mcpServer *mcp.Server, h handler, name string) {
 	switch name {
+	case "go_call_hierarchy":
+		mcp.AddTool(mcpServer, &mcp.Tool{
+			Name: "go_call_hierarchy",
+			Description: `Provides the trees of incoming and outgoing calls of a (possibly
+qualified) Go function or method referenced from the current file.
+
+Each line of the result describes one caller (for incoming calls) or callee
+(for outgoing calls), followed by the file and line numbers of the calls.
+Nested lines, indented by depth, describe the callers of callers, or the
+callees of callees. Symbols are named as for go_symbol_references.
+
+For example, given arguments {"file": "/path/to/foo.go", "symbol": "Server.Run",
+"direction": "incoming", "depth": 2}, go_call_hierarchy reports the callers of
+the Run method of type Server, and their callers. The direction may be
+"incoming", "outgoing", or empty for both; the depth defaults to 1, and may be
+at most 5.
+`,
+		}, h.callHierarchyHandler)
+	case "go_check_patch":
+		mcp.AddTool(mcpServer, &mcp.Tool{
+			Name: "go_check_patch",
+			Description: `Reports the diagnostics that would result from applying a patch to the
+workspace, without modifying any files.
+
+The patch is a unified diff of one or more Go or go.mod files, such as the
+output of 'diff -u' or 'git diff'. File paths are absolute, or relative to the
+workspace root. Files may be created (from /dev/null), but not deleted or
+renamed.
+
+Use go_check_patch to verify an edit before making it: if the result reports
+errors, revise the patch and check it again. If a hunk does not match the
+current content of its file, the tool reports an error.
+`,
+		}, h.checkPatchHandler)
+	case "go_code_actions":
+		mcp.AddTool(mcpServer, &mcp.Tool{
+			Name: "go_code_actions",
+			Description: `Lists the code actions that gopls can apply at a selection in a Go file.
+
+Code actions include quick fixes for the diagnostics at the selection (such as
+adding a missing import or declaring an undefined function) and refactorings
+(such as filling in a struct literal or the cases of a switch, extracting a
+function or variable, inlining a call, or implementing the missing methods of
+an interface). These transformations are type-aware, and therefore safer than
+editing the text by hand.
+
+The selection is given by 1-based line and byte column numbers; if the end is
+omitted, the selection is empty. The optional kind restricts the result to
+actions of that kind and its subkinds, such as "quickfix", "refactor.extract",
+"refactor.inline", "refactor.rewrite", or "source.organizeImports".
+
+Each line of the result reports the title of an action, followed by its kind.
+Use go_code_action_edits to see the edits of an action.
+`,
+		}, h.codeActionsHandler)
+	case "go_code_action_edits":
+		mcp.AddTool(mcpServer, &mcp.Tool{
+			Name: "go_code_action_edits",
+			Description: `Computes the edits of a code action listed by go_code_actions.
+
+Given the same file and selection, and the title of the action, it returns the
+changes as a unified diff. The files are not modified: apply the diff to make
+the changes.
+
+For example, given arguments {"file": "/path/to/foo.go", "start_line": 12,
+"start_column": 5, "title": "Fill Point"}, go_code_action_edits returns the
+changes that fill in the missing fields of the Point struct literal at line 12.
+`,
+		}, h.codeActionEditsHandler)
 	case "go_context":
 		mcp.AddTool(mcpServer, &mcp.Tool{
 			Name:        "go_context",
 			Description: "Provide context for a region within a Go file",
 		}, h.contextHandler)
+	case "go_definition":
+		mcp.AddTool(mcpServer, &mcp.Tool{
+			Name: "go_definition",
+			Description: `Describes a Go symbol: the location of its declaration, its
+signature, and its documentation.
+
+The symbol is identified either by name, as for go_symbol_references (for
+example, {"file": "/path/to/foo.go", "symbol": "http.Client.Do"}), or by the
+1-based line and byte column of an identifier in the file, which may refer to
+a symbol declared elsewhere (for example, {"file": "/path/to/foo.go", "line":
+12, "column": 8}). For a type, the signature is followed by its methods.
+`,
+		}, h.definitionHandler)
 	case "go_diagnostics":
 		mcp.AddTool(mcpServer, &mcp.Tool{
 			Name: "go_diagnostics",
@@ -298,6 +457,39 @@ performed.
 			Name:        "go_file_metadata",
 			Description: "Provides metadata about the Go package containing the file",
 		}, h.fileMetadataHandler)
+	case "go_implementations":
+		mcp.AddTool(mcpServer, &mcp.Tool{
+			Name: "go_implementations",
+			Description: `Provides the locations of the implementations of a (possibly
+qualified) Go type or method referenced from the current file.
+
+For an interface type, go_implementations reports the concrete types that
+implement it, along with any other interfaces that it implements or that
+implement it. For a concrete type, it reports the interfaces that the type
+implements. For a method, it reports the corresponding methods of those types.
+
+Symbols are named as for go_symbol_references: for example, given arguments
+{"file": "/path/to/foo.go", "symbol": "io.Reader"}, go_implementations reports
+the implementations of io.Reader, and "T.M" selects the method M of type T.
+`,
+		}, h.implementationsHandler)
+	case "go_module_graph":
+		mcp.AddTool(mcpServer, &mcp.Tool{
+			Name: "go_module_graph",
+			Description: `Summarizes the module graph of the main modules of the Go workspace.
+
+For each main module, it lists the modules of the build list with the versions
+selected by minimal version selection, separated into direct requirements and
+indirect dependencies. It reports the version required by the go.mod file when
+it differs from the selected version, the replacement of a replaced module, and
+for an indirect dependency, the modules that require it.
+
+If "go_mod" is set, only the main module of that go.mod file is summarized.
+If "upgrades" is set, it queries the module proxy for the newest version of
+each module, which requires network access; use this before deciding to add
+or upgrade a dependency.
+`,
+		}, h.moduleGraphHandler)
 	case "go_package_api":
 		mcp.AddTool(mcpServer, &mcp.Tool{
 			Name:        "go_package_api",
@@ -348,6 +540,41 @@ Finally, symbol references supporting querying fields and methods: symbol
 does the same for a symbol in the imported package "lib".
 `,
 		}, h.symbolReferencesHandler)
+	case "go_test":
+		mcp.AddTool(mcpServer, &mcp.Tool{
+			Name: "go_test",
+			Description: `Runs Go tests and summarizes the results.
+
+The tests to run are selected by one of:
+- a package import path or pattern, such as "example.com/foo" or "./...",
+  optionally with a "run" regular expression, as for 'go test -run';
+- a file, whose package is tested, optionally with a "run" expression;
+- a file and a (possibly qualified) symbol, to run the tests, in any package,
+  that refer directly to that function or method.
+Symbols are named as for go_symbol_references.
+
+For each package, the result reports whether its tests passed, and how many
+tests passed, failed, or were skipped. For each failed test, it reports only
+the messages logged by the test (t.Error, t.Fatal, and so on), or the start of
+the output of a panic. Build errors are reported instead of test results.
+
+For example, given arguments {"file": "/path/to/foo.go", "symbol": "Parse"},
+go_test runs the tests that call the Parse function declared in foo.go.
+`,
+		}, h.testHandler)
+	case "go_type_hierarchy":
+		mcp.AddTool(mcpServer, &mcp.Tool{
+			Name: "go_type_hierarchy",
+			Description: `Provides the supertypes and subtypes of a (possibly qualified) Go
+type referenced from the current file.
+
+The supertypes of a type are the interfaces it implements; the subtypes of an
+interface are the types that implement it. Symbols are named as for
+go_symbol_references: for example, given arguments {"file": "/path/to/foo.go",
+"symbol": "lib.T"}, go_type_hierarchy reports the type hierarchy of type T in
+the imported package lib.
+`,
+		}, h.typeHierarchyHandler)
 	case "go_workspace":
 		mcp.AddTool(mcpServer, &mcp.Tool{
 			Name:        "go_workspace",
@@ -369,9 +596,35 @@ does the same for a symbol in the imported package "lib".
 	}
 }
 
+// views returns the views on which the workspace queries of the MCP
+// client operate. If the client reported workspace roots, these are the
+// views within a root, followed by the views that contain a root;
+// otherwise, or if there are no such views, they are all the views of
+// the session.
+func (h *handler) views() []*cache.View {
+	var (
+		views    = h.session.Views()
+		dirs     = h.roots.get()
+		within   []*cache.View
+		enclosed []*cache.View
+	)
+	for _, v := range views {
+		switch {
+		case slices.ContainsFunc(dirs, func(dir protocol.DocumentURI) bool { return dir.Encloses(v.Root()) }):
+			within = append(within, v)
+		case slices.ContainsFunc(dirs, func(dir protocol.DocumentURI) bool { return v.Root().Encloses(dir) }):
+			enclosed = append(enclosed, v)
+		}
+	}
+	if selected := append(within, enclosed...); len(selected) > 0 {
+		return selected
+	}
+	return views
+}
+
 // snapshot returns the best default snapshot to use for workspace queries.
 func (h *handler) snapshot() (*cache.Snapshot, func(), error) {
-	views := h.session.Views()
+	views := h.views()
 	if len(views) == 0 {
 		return nil, nil, fmt.Errorf("No active builds.")
 	}
@@ -39,7 +39,7 @@ func TestContextCancellation(t *testing.T) {
 
 	res := make(chan error)
 	go func() {
-		res <- internalmcp.Serve(ctx, "localhost:0", emptySessions{}, true, nil)
+		res <- internalmcp.Serve(ctx, "localhost:0", internalmcp.SSE, emptySessions{}, true, nil)
 	}()
 
 	time.Sleep(1 * time.Second)
@@ -154,3 +154,34 @@ func TestClientRootChange(t *testing.T) {
 		t.Fatal("Timeout waiting for updated roots.")
 	}
 }
+
+func TestOutputSchemas(t *testing.T) {
+	server := internalmcp.NewServer(nil, nil, nil)
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil)
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+
+	ctx := t.Context()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer serverSession.Close()
+	clientSession, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clientSession.Close()
+
+	res, err := clientSession.ListTools(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Tools) == 0 {
+		t.Fatal("no tools")
+	}
+	for _, tool := range res.Tools {
+		if tool.OutputSchema == nil {
+			t.Errorf("tool %s has no output schema", tool.Name)
+		}
+	}
+}
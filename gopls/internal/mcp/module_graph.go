@@ -0,0 +1,141 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package mcp
+
+// This file defines the "go_module_graph" tool, which summarizes the
+// dependencies of the main modules of the workspace.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/mod"
+	"golang.org/x/tools/gopls/internal/protocol"
+)
+
+type moduleGraphParams struct {
+	GoMod    string `json:"go_mod,omitempty" jsonschema:"the absolute path to the go.mod file of a main module; if empty, all main modules of the workspace"`
+	Upgrades bool   `json:"upgrades,omitempty" jsonschema:"if set, query the module proxy for available upgrades, which requires network access"`
+}
+
+type moduleGraphResult struct {
+	Modules []mainModule `json:"modules" jsonschema:"the main modules"`
+}
+
+// A mainModule describes the dependencies of a main module.
+type mainModule struct {
+	Path         string       `json:"path" jsonschema:"the module path"`
+	GoMod        string       `json:"go_mod" jsonschema:"the absolute path to the go.mod file"`
+	Dependencies []dependency `json:"dependencies" jsonschema:"the modules of the build list, other than the main module"`
+}
+
+// A dependency is a module of the build list of a main module.
+type dependency struct {
+	Path       string   `json:"path" jsonschema:"the module path"`
+	Version    string   `json:"version" jsonschema:"the version selected by minimal version selection"`
+	Required   string   `json:"required,omitempty" jsonschema:"the version required by the go.mod file of the main module, if any"`
+	Direct     bool     `json:"direct" jsonschema:"whether the main module requires the module directly, rather than only indirectly"`
+	Replace    string   `json:"replace,omitempty" jsonschema:"the replacement of the module, as a module path and version, or a directory"`
+	Update     string   `json:"update,omitempty" jsonschema:"the newest available version, if known and newer than the selected version"`
+	RequiredBy []string `json:"required_by,omitempty" jsonschema:"the paths of the modules that require the module"`
+}
+
+func (h *handler) moduleGraphHandler(ctx context.Context, req *mcp.CallToolRequest, params moduleGraphParams) (*mcp.CallToolResult, *moduleGraphResult, error) {
+	countGoModuleGraphMCP.Inc()
+	var (
+		snapshot *cache.Snapshot
+		release  func()
+		err      error
+	)
+	if params.GoMod != "" {
+		snapshot, release, err = h.session.SnapshotOf(ctx, protocol.URIFromPath(params.GoMod))
+	} else {
+		snapshot, release, err = h.snapshot()
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	defer release()
+
+	modFiles := snapshot.View().ModFiles()
+	if params.GoMod != "" {
+		modFiles = []protocol.DocumentURI{protocol.URIFromPath(params.GoMod)}
+	}
+	if len(modFiles) == 0 {
+		return textResult("The workspace has no main modules."), nil, nil
+	}
+
+	var (
+		b      strings.Builder
+		result moduleGraphResult
+	)
+	for _, modURI := range modFiles {
+		g, err := mod.ModuleGraph(ctx, snapshot, modURI, params.Upgrades)
+		if err != nil {
+			return nil, nil, fmt.Errorf("computing the module graph of %s: %v", modURI.Path(), err)
+		}
+		mm := mainModule{Path: g.Path, GoMod: g.GoMod.Path(), Dependencies: []dependency{}}
+		var direct, indirect []dependency
+		for _, m := range g.Modules {
+			dep := dependency{
+				Path:       m.Path,
+				Version:    m.Version,
+				Required:   m.Required,
+				Direct:     m.Direct,
+				Update:     m.Update,
+				RequiredBy: m.RequiredBy,
+			}
+			if m.Replace != nil {
+				dep.Replace = strings.TrimSpace(m.Replace.Path + " " + m.Replace.Version)
+			}
+			mm.Dependencies = append(mm.Dependencies, dep)
+			if dep.Direct {
+				direct = append(direct, dep)
+			} else {
+				indirect = append(indirect, dep)
+			}
+		}
+		result.Modules = append(result.Modules, mm)
+
+		fmt.Fprintf(&b, "Module %s (`%s`) has %d direct and %d indirect dependencies.\n", g.Path, g.GoMod.Path(), len(direct), len(indirect))
+		writeDependencies(&b, "Direct requirements", direct)
+		writeDependencies(&b, "Indirect dependencies", indirect)
+		b.WriteString("\n")
+	}
+	if !params.Upgrades {
+		b.WriteString("To check for available upgrades, set \"upgrades\": true.\n")
+	}
+	return textResult(b.String()), &result, nil
+}
+
+// writeDependencies writes a titled list of dependencies, one per line.
+func writeDependencies(b *strings.Builder, title string, deps []dependency) {
+	if len(deps) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n%s:\n", title)
+	for _, dep := range deps {
+		fmt.Fprintf(b, "\t%s %s", dep.Path, dep.Version)
+		var notes []string
+		if dep.Required != "" && dep.Required != dep.Version {
+			notes = append(notes, "go.mod requires "+dep.Required)
+		}
+		if dep.Replace != "" {
+			notes = append(notes, "replaced by "+dep.Replace)
+		}
+		if dep.Update != "" {
+			notes = append(notes, "upgrade available: "+dep.Update)
+		}
+		if !dep.Direct && len(dep.RequiredBy) > 0 {
+			notes = append(notes, "required by "+strings.Join(dep.RequiredBy, ", "))
+		}
+		if len(notes) > 0 {
+			fmt.Fprintf(b, " (%s)", strings.Join(notes, "; "))
+		}
+		b.WriteString("\n")
+	}
+}
@@ -17,7 +17,17 @@ type outlineParams struct {
 	PackagePaths []string `json:"packagePaths" jsonschema:"the go package paths to describe"`
 }
 
-func (h *handler) outlineHandler(ctx context.Context, req *mcp.CallToolRequest, params outlineParams) (*mcp.CallToolResult, any, error) {
+type packageAPIResult struct {
+	Packages []packageAPI `json:"packages"`
+}
+
+// A packageAPI is the API of a package.
+type packageAPI struct {
+	Package packageInfo `json:"package"`
+	API     string      `json:"api" jsonschema:"the declarations of the exported symbols of the package, as Go source, with their documentation"`
+}
+
+func (h *handler) outlineHandler(ctx context.Context, req *mcp.CallToolRequest, params outlineParams) (*mcp.CallToolResult, *packageAPIResult, error) {
 	countGoPackageAPIMCP.Inc()
 	snapshot, release, err := h.snapshot()
 	if err != nil {
@@ -45,16 +55,20 @@ func (h *handler) outlineHandler(ctx context.Context, req *mcp.CallToolRequest,
 		}
 	}
 
-	var content []mcp.Content
+	var (
+		content = []mcp.Content{} // non-nil, so that it is not replaced by the JSON of the result
+		result  packageAPIResult
+	)
 	for _, mp := range toSummarize {
 		if md == nil {
 			continue // ignore error
 		}
 		if summary := summarizePackage(ctx, snapshot, mp); summary != "" {
 			content = append(content, &mcp.TextContent{Text: summary})
+			result.Packages = append(result.Packages, packageAPI{Package: newPackageInfo(mp), API: summary})
 		}
 	}
 	return &mcp.CallToolResult{
 		Content: content,
-	}, nil, nil
+	}, &result, nil
 }
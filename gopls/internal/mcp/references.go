@@ -20,7 +20,17 @@ type findReferencesParams struct {
 	Location protocol.Location `json:"location"`
 }
 
-func (h *handler) referencesHandler(ctx context.Context, req *mcp.CallToolRequest, params findReferencesParams) (*mcp.CallToolResult, any, error) {
+type referencesResult struct {
+	References []reference `json:"references"`
+}
+
+// A reference is a reference to a symbol.
+type reference struct {
+	Location location `json:"location"`
+	Line     string   `json:"line,omitempty" jsonschema:"the content of the line of the reference, without leading space"`
+}
+
+func (h *handler) referencesHandler(ctx context.Context, req *mcp.CallToolRequest, params findReferencesParams) (*mcp.CallToolResult, *referencesResult, error) {
 	countGoReferencesMCP.Inc()
 	fh, snapshot, release, err := h.session.FileOf(ctx, params.Location.URI)
 	if err != nil {
@@ -32,17 +42,21 @@ func (h *handler) referencesHandler(ctx context.Context, req *mcp.CallToolReques
 	if err != nil {
 		return nil, nil, err
 	}
-	formatted, err := formatReferences(ctx, snapshot, refs)
-	return formatted, nil, err
+	return formatReferences(ctx, snapshot, refs)
 }
 
-func formatReferences(ctx context.Context, snapshot *cache.Snapshot, refs []protocol.Location) (*mcp.CallToolResult, error) {
+func formatReferences(ctx context.Context, snapshot *cache.Snapshot, refs []protocol.Location) (*mcp.CallToolResult, *referencesResult, error) {
 	if len(refs) == 0 {
-		return nil, fmt.Errorf("no references found")
+		return nil, nil, fmt.Errorf("no references found")
 	}
-	var builder strings.Builder
+	var (
+		builder strings.Builder
+		result  referencesResult
+		loc     = newLocator(ctx, snapshot)
+	)
 	fmt.Fprintf(&builder, "The object has %v references. Their locations are listed below\n", len(refs))
 	for i, r := range refs {
+		result.References = append(result.References, reference{Location: loc.location(r)})
 		fmt.Fprintf(&builder, "Reference %d\n", i+1)
 		fmt.Fprintf(&builder, "Located in the file: %s\n", filepath.ToSlash(r.URI.Path()))
 		refFh, err := snapshot.ReadFile(ctx, r.URI)
@@ -62,8 +76,9 @@ func formatReferences(ctx context.Context, snapshot *cache.Snapshot, refs []prot
 		} else {
 			continue
 		}
+		result.References[i].Line = lineContent
 		fmt.Fprintf(&builder, "The reference is located on line %v, which has content `%s`\n", r.Range.Start.Line, lineContent)
 		builder.WriteString("\n")
 	}
-	return textResult(builder.String()), nil
+	return textResult(builder.String()), &result, nil
 }
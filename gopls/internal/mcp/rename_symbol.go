@@ -22,7 +22,7 @@ type renameSymbolParams struct {
 	NewName string `json:"new_name" jsonschema:"the new name for the symbol"`
 }
 
-func (h *handler) renameSymbolHandler(ctx context.Context, req *mcp.CallToolRequest, params renameSymbolParams) (*mcp.CallToolResult, any, error) {
+func (h *handler) renameSymbolHandler(ctx context.Context, req *mcp.CallToolRequest, params renameSymbolParams) (*mcp.CallToolResult, *editsResult, error) {
 	countGoRenameSymbolMCP.Inc()
 	fh, snapshot, release, err := h.fileOf(ctx, params.File)
 	if err != nil {
@@ -42,18 +42,25 @@ func (h *handler) renameSymbolHandler(ctx context.Context, req *mcp.CallToolRequ
 		return nil, nil, err
 	}
 	var builder strings.Builder
-	if err := formatRenameChanges(ctx, snapshot, &builder, changes); err != nil {
+	diffs, err := formatRenameChanges(ctx, snapshot, &builder, changes)
+	if err != nil {
 		return nil, nil, err
 	}
-	return textResult(builder.String()), nil, nil
+	return textResult(builder.String()), &editsResult{Changes: diffs}, nil
 }
 
-// formatRenameChanges converts the list of DocumentChange to a unified diff and writes them to the specified buffer.
-func formatRenameChanges(ctx context.Context, snapshot *cache.Snapshot, w *strings.Builder, changes []protocol.DocumentChange) error {
+// formatRenameChanges converts the list of DocumentChange to unified diffs,
+// writes them to the specified buffer, and returns them.
+func formatRenameChanges(ctx context.Context, snapshot *cache.Snapshot, w *strings.Builder, changes []protocol.DocumentChange) ([]fileDiff, error) {
+	diffs, err := unifiedDiffs(ctx, snapshot, changes)
+	if err != nil {
+		return nil, err
+	}
 	w.WriteString("The following changes are necessary to rename the symbol:\n")
-	if err := writeUnifiedDiff(ctx, snapshot, w, changes); err != nil {
-		return err
+	for _, d := range diffs {
+		w.WriteString(d.Diff)
+		w.WriteString("\n")
 	}
 	w.WriteString("\n")
-	return nil
+	return diffs, nil
 }
@@ -0,0 +1,125 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package mcp
+
+// This file defines the types shared by the structured results of the
+// tools. Each tool returns its result both as text, for models, and as
+// structured content (described by the tool's output schema), for
+// clients that process results programmatically.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/cache/metadata"
+	"golang.org/x/tools/gopls/internal/protocol"
+)
+
+// A location is a range of a file. Lines and columns are 1-based, and
+// columns are measured in bytes, as in the selections of go_code_actions.
+type location struct {
+	File        string `json:"file" jsonschema:"the absolute path to the file"`
+	StartLine   int    `json:"start_line" jsonschema:"the 1-based line number of the start of the range"`
+	StartColumn int    `json:"start_column" jsonschema:"the 1-based byte column of the start of the range"`
+	EndLine     int    `json:"end_line" jsonschema:"the 1-based line number of the end of the range"`
+	EndColumn   int    `json:"end_column" jsonschema:"the 1-based byte column of the end of the range"`
+}
+
+// A locator converts protocol locations, whose columns are measured
+// in UTF-16 code units, to locations.
+type locator struct {
+	ctx      context.Context
+	snapshot *cache.Snapshot
+	mappers  map[protocol.DocumentURI]*protocol.Mapper // nil if the file can't be read
+}
+
+func newLocator(ctx context.Context, snapshot *cache.Snapshot) *locator {
+	return &locator{ctx: ctx, snapshot: snapshot, mappers: make(map[protocol.DocumentURI]*protocol.Mapper)}
+}
+
+// location returns the location of loc. If its file can't be read,
+// the columns are those of loc.
+func (l *locator) location(loc protocol.Location) location {
+	res := location{
+		File:        loc.URI.Path(),
+		StartLine:   int(loc.Range.Start.Line) + 1,
+		StartColumn: int(loc.Range.Start.Character) + 1,
+		EndLine:     int(loc.Range.End.Line) + 1,
+		EndColumn:   int(loc.Range.End.Character) + 1,
+	}
+	m, ok := l.mappers[loc.URI]
+	if !ok {
+		if fh, err := l.snapshot.ReadFile(l.ctx, loc.URI); err == nil {
+			if content, err := fh.Content(); err == nil {
+				m = protocol.NewMapper(loc.URI, content)
+			}
+		}
+		l.mappers[loc.URI] = m
+	}
+	if m != nil {
+		if start, end, err := m.RangeOffsets(loc.Range); err == nil {
+			res.StartLine, res.StartColumn = m.OffsetLineCol8(start)
+			res.EndLine, res.EndColumn = m.OffsetLineCol8(end)
+		}
+	}
+	return res
+}
+
+// locations returns the locations of locs.
+func (l *locator) locations(locs []protocol.Location) []location {
+	res := make([]location, len(locs))
+	for i, loc := range locs {
+		res[i] = l.location(loc)
+	}
+	return res
+}
+
+// A packageInfo describes a package.
+type packageInfo struct {
+	Path  string   `json:"path" jsonschema:"the package path"`
+	Name  string   `json:"name" jsonschema:"the package name"`
+	Files []string `json:"files,omitempty" jsonschema:"the absolute paths to the Go files of the package"`
+}
+
+// newPackageInfo returns the description of a package.
+func newPackageInfo(mp *metadata.Package) packageInfo {
+	info := packageInfo{Path: string(mp.PkgPath), Name: string(mp.Name)}
+	for _, uri := range mp.CompiledGoFiles {
+		info.Files = append(info.Files, uri.Path())
+	}
+	return info
+}
+
+// A diagnostic is a problem reported in a file.
+type diagnostic struct {
+	Location location `json:"location"`
+	Severity string   `json:"severity" jsonschema:"the severity of the diagnostic: error, warning, information, or hint"`
+	Code     string   `json:"code,omitempty" jsonschema:"the code of the diagnostic, such as a type checker error code or an analyzer category"`
+	Source   string   `json:"source,omitempty" jsonschema:"the source of the diagnostic, such as 'compiler' or the name of an analyzer"`
+	Message  string   `json:"message"`
+	Fix      string   `json:"fix,omitempty" jsonschema:"the unified diff of the preferred quick fix of the diagnostic, if any"`
+}
+
+type diagnosticsResult struct {
+	Diagnostics []diagnostic `json:"diagnostics"`
+}
+
+// A fileDiff is the unified diff of the change to a file.
+type fileDiff struct {
+	File string `json:"file" jsonschema:"the absolute path to the changed file"`
+	Diff string `json:"diff" jsonschema:"the unified diff of the change"`
+}
+
+// An editsResult is the result of the tools that compute, but don't
+// apply, edits to the workspace.
+type editsResult struct {
+	Changes []fileDiff `json:"changes" jsonschema:"the changes to files, which have not been applied"`
+}
+
+// severityName returns the name of a severity, such as "error".
+func severityName(s protocol.DiagnosticSeverity) string {
+	return strings.ToLower(fmt.Sprint(s))
+}
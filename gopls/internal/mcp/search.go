@@ -17,7 +17,18 @@ type searchParams struct {
 	Query string `json:"query" jsonschema:"the fuzzy search query to use for matching symbols"`
 }
 
-func (h *handler) searchHandler(ctx context.Context, req *mcp.CallToolRequest, params searchParams) (*mcp.CallToolResult, any, error) {
+type searchResult struct {
+	Symbols []symbol `json:"symbols"`
+}
+
+// A symbol is a named declaration.
+type symbol struct {
+	Name     string   `json:"name" jsonschema:"the name of the symbol, qualified by its type if it is a field or method"`
+	Kind     string   `json:"kind" jsonschema:"the kind of the symbol, such as Function, Method, Type, Interface, or Variable"`
+	Location location `json:"location"`
+}
+
+func (h *handler) searchHandler(ctx context.Context, req *mcp.CallToolRequest, params searchParams) (*mcp.CallToolResult, *searchResult, error) {
 	countGoSearchMCP.Inc()
 	query := params.Query
 	if len(query) == 0 {
@@ -32,12 +43,37 @@ func (h *handler) searchHandler(ctx context.Context, req *mcp.CallToolRequest, p
 	if len(syms) == 0 {
 		return textResult("No symbols found."), nil, nil
 	}
-	var b strings.Builder
+	snapshot, release, err := h.snapshot()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer release()
+
+	var (
+		b      strings.Builder
+		result searchResult
+		loc    = newLocator(ctx, snapshot)
+	)
 	fmt.Fprintf(&b, "Top symbol matches:\n")
 	for _, sym := range syms {
-		fmt.Fprintf(&b, "\t%s (%s in `%s`)\n", sym.Name, kindName(sym.Kind), sym.Location.URI.Path())
+		// The location specifies only the file if the LSP client
+		// resolves the ranges of workspace symbols lazily.
+		if _, ok := sym.Location.Value.(protocol.Location); !ok {
+			resolved, err := h.lspServer.ResolveWorkspaceSymbol(ctx, &sym)
+			if err != nil {
+				return nil, nil, err
+			}
+			sym = *resolved
+		}
+		symLoc, _ := sym.Location.Value.(protocol.Location)
+		fmt.Fprintf(&b, "\t%s (%s in `%s`)\n", sym.Name, kindName(sym.Kind), symLoc.URI.Path())
+		result.Symbols = append(result.Symbols, symbol{
+			Name:     sym.Name,
+			Kind:     kindName(sym.Kind),
+			Location: loc.location(symLoc),
+		})
 	}
-	return textResult(b.String()), nil, nil
+	return textResult(b.String()), &result, nil
 }
 
 // kindName returns the adjusted name for the given symbol kind,
@@ -29,7 +29,7 @@ type symbolReferencesParams struct {
 // symbolReferencesHandler is the handler for the "go_symbol_references" tool.
 // It finds all references to the requested symbol and describes their
 // locations.
-func (h *handler) symbolReferencesHandler(ctx context.Context, req *mcp.CallToolRequest, params symbolReferencesParams) (*mcp.CallToolResult, any, error) {
+func (h *handler) symbolReferencesHandler(ctx context.Context, req *mcp.CallToolRequest, params symbolReferencesParams) (*mcp.CallToolResult, *referencesResult, error) {
 	countGoSymbolReferencesMCP.Inc()
 	fh, snapshot, release, err := h.fileOf(ctx, params.File)
 	if err != nil {
@@ -53,8 +53,7 @@ func (h *handler) symbolReferencesHandler(ctx context.Context, req *mcp.CallTool
 	if err != nil {
 		return nil, nil, err
 	}
-	formatted, err := formatReferences(ctx, snapshot, refs)
-	return formatted, nil, err
+	return formatReferences(ctx, snapshot, refs)
 }
 
 // symbolLocation returns the protocol.Location of the given symbol within the file uri, or an error if it cannot be located.
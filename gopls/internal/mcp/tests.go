@@ -0,0 +1,495 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package mcp
+
+// This file defines the "go_test" tool, which runs tests and
+// summarizes their results.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/file"
+	"golang.org/x/tools/gopls/internal/golang"
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/util/moremaps"
+)
+
+// maxFailureLines is the maximum number of lines of output reported
+// for each failed test.
+const maxFailureLines = 20
+
+type testParams struct {
+	Package string `json:"package,omitempty" jsonschema:"the import path or pattern of the packages to test, such as example.com/foo or ./... (default: the package of file)"`
+	File    string `json:"file,omitempty" jsonschema:"the absolute path to a Go file, which determines the package to test, and in which symbol is resolved"`
+	Run     string `json:"run,omitempty" jsonschema:"a regular expression selecting the tests to run, as for 'go test -run'"`
+	Symbol  string `json:"symbol,omitempty" jsonschema:"if set, run the tests, in any package, that refer to this (possibly qualified) function or method"`
+}
+
+type testResult struct {
+	Tests    []string      `json:"tests,omitempty" jsonschema:"the tests that were run, if they were selected by symbol"`
+	Packages []testPackage `json:"packages"`
+}
+
+// A testPackage is the result of the tests of a package.
+type testPackage struct {
+	Package     string        `json:"package" jsonschema:"the path of the package"`
+	Status      string        `json:"status" jsonschema:"the status of the package: ok, fail, build failed, or no tests (if it has no tests to run)"`
+	Passed      []string      `json:"passed,omitempty" jsonschema:"the top-level tests that passed"`
+	Failed      []string      `json:"failed,omitempty" jsonschema:"the tests, including subtests, that failed"`
+	Skipped     []string      `json:"skipped,omitempty" jsonschema:"the top-level tests that were skipped"`
+	Failures    []testFailure `json:"failures,omitempty" jsonschema:"the relevant output of the failures"`
+	BuildErrors []string      `json:"build_errors,omitempty" jsonschema:"the errors of a failed build"`
+}
+
+// A testFailure is the relevant output of a failure.
+type testFailure struct {
+	Test   string   `json:"test,omitempty" jsonschema:"the failed test, or empty if the package failed outside of any test, as after a panic in TestMain or a timeout"`
+	Output []string `json:"output" jsonschema:"the relevant lines of the output, such as the messages logged by the test, or the message and stack frames of a panic"`
+}
+
+func (h *handler) testHandler(ctx context.Context, req *mcp.CallToolRequest, params testParams) (*mcp.CallToolResult, *testResult, error) {
+	countGoTestMCP.Inc()
+	if params.Symbol != "" && params.Run != "" {
+		return nil, nil, fmt.Errorf("run and symbol are mutually exclusive")
+	}
+	if params.Symbol != "" && params.File == "" {
+		return nil, nil, fmt.Errorf("symbol requires a file in which to resolve it")
+	}
+
+	var (
+		snapshot *cache.Snapshot
+		release  func()
+		fh       file.Handle
+		err      error
+	)
+	if params.File != "" {
+		fh, snapshot, release, err = h.fileOf(ctx, params.File)
+	} else if params.Package != "" {
+		snapshot, release, err = h.snapshot()
+	} else {
+		return nil, nil, fmt.Errorf("either package or file must be set")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	defer release()
+
+	// dir is the working directory of the go command.
+	dir := snapshot.View().Root().Path()
+	if fh != nil {
+		dir = fh.URI().DirPath()
+	}
+
+	var (
+		b      strings.Builder
+		result testResult
+		runs   []testRun
+	)
+	switch {
+	case params.Symbol != "":
+		tests, err := coveringTests(ctx, snapshot, fh, params.Symbol)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(tests) == 0 {
+			return textResult(fmt.Sprintf("No tests refer to %s.\n", params.Symbol)), nil, nil
+		}
+		var names []string
+		for pkg, tests := range moremaps.Sorted(tests) {
+			quoted := make([]string, len(tests))
+			for i, test := range tests {
+				quoted[i] = regexp.QuoteMeta(test)
+			}
+			runs = append(runs, testRun{pkg, "^(" + strings.Join(quoted, "|") + ")$"})
+			names = append(names, tests...)
+		}
+		result.Tests = names
+		fmt.Fprintf(&b, "Running the tests that refer to %s: %s\n\n", params.Symbol, strings.Join(names, ", "))
+
+	case params.Package != "":
+		if err := checkPackagePattern(params.Package); err != nil {
+			return nil, nil, err
+		}
+		runs = []testRun{{params.Package, params.Run}}
+
+	default:
+		mp, err := snapshot.NarrowestMetadataForFile(ctx, fh.URI())
+		if err != nil {
+			return nil, nil, err
+		}
+		pkg := string(mp.PkgPath)
+		if mp.ForTest != "" {
+			pkg = string(mp.ForTest)
+		}
+		runs = []testRun{{pkg, params.Run}}
+	}
+
+	summary := newTestSummary()
+	for _, run := range runs {
+		if err := runTests(ctx, snapshot, dir, run, summary); err != nil {
+			return nil, nil, err
+		}
+	}
+	result.Packages = summary.results()
+	writeTestResults(&b, result.Packages)
+	return textResult(b.String()), &result, nil
+}
+
+// checkPackagePattern returns an error if pattern is not an import
+// path or package pattern, such as example.com/foo/... or ./..., so
+// that the go command can't interpret it as a flag.
+func checkPackagePattern(pattern string) error {
+	if strings.HasPrefix(pattern, "-") {
+		return fmt.Errorf("invalid package pattern %q: must not start with '-'", pattern)
+	}
+	for _, r := range pattern {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("-._~+/", r) {
+			return fmt.Errorf("invalid package pattern %q: invalid character %q", pattern, r)
+		}
+	}
+	return nil
+}
+
+// A testRun describes a single invocation of 'go test'.
+type testRun struct {
+	pattern string // package pattern
+	run     string // -run flag; empty for all
+}
+
+// runTests runs 'go test' as described by run, and records the results
+// in the summary.
+func runTests(ctx context.Context, snapshot *cache.Snapshot, dir string, run testRun, summary *testSummary) error {
+	args := []string{"-json", "-count=1"}
+	if run.run != "" {
+		args = append(args, "-run="+run.run)
+	}
+	args = append(args, run.pattern)
+	inv, cleanup, err := snapshot.GoCommandInvocation(cache.NoNetwork, dir, "test", args)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	stdout, stderr, friendlyErr, _ := snapshot.View().GoCommandRunner().RunRaw(ctx, *inv)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	// Test failures cause the command to fail, so only report its
+	// error if it produced no results.
+	n, err := summary.add(stdout.Bytes())
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		if friendlyErr != nil {
+			return friendlyErr
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return errors.New(msg)
+		}
+	}
+	return nil
+}
+
+// coveringTests returns the names of the tests, benchmarks excluded,
+// that refer directly to the specified symbol, grouped by the path of
+// the package under test.
+func coveringTests(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle, symbol string) (map[string][]string, error) {
+	loc, err := symbolLocation(ctx, snapshot, fh.URI(), symbol)
+	if err != nil {
+		return nil, err
+	}
+	declFH, err := snapshot.ReadFile(ctx, loc.URI)
+	if err != nil {
+		return nil, err
+	}
+	refs, err := golang.References(ctx, snapshot, declFH, loc.Range, false)
+	if err != nil {
+		return nil, err
+	}
+
+	tests := make(map[string][]string)
+	for _, ref := range refs {
+		if !strings.HasSuffix(ref.URI.Path(), "_test.go") {
+			continue
+		}
+		mp, err := snapshot.NarrowestMetadataForFile(ctx, ref.URI)
+		if err != nil {
+			return nil, err
+		}
+		indexes, err := snapshot.Tests(ctx, mp.ID)
+		if err != nil {
+			return nil, err
+		}
+		pkg := string(mp.PkgPath)
+		if mp.ForTest != "" {
+			pkg = string(mp.ForTest)
+		}
+		for _, test := range indexes[0].All() {
+			if test.Location.URI == ref.URI &&
+				protocol.Intersect(test.Location.Range, ref.Range) &&
+				!strings.Contains(test.Name, "/") && // subtests are identified by name only
+				!strings.HasPrefix(test.Name, "Benchmark") &&
+				!slices.Contains(tests[pkg], test.Name) {
+				tests[pkg] = append(tests[pkg], test.Name)
+			}
+		}
+	}
+	return tests, nil
+}
+
+// A testEvent is an event reported by 'go test -json'.
+// See 'go doc test2json'.
+type testEvent struct {
+	Action     string
+	Package    string
+	ImportPath string // of "build-output" events
+	Test       string
+	Output     string
+}
+
+// A testSummary accumulates the results of 'go test -json'.
+type testSummary struct {
+	packages map[string]*packageTests
+}
+
+// packageTests records the tests results of one package.
+type packageTests struct {
+	result                  string // final action of the package: "pass", "fail", or "skip"
+	passed, failed, skipped []string
+	output                  map[string][]string // output lines of each test ("" for package-level output)
+	buildOutput             []string
+}
+
+func newTestSummary() *testSummary {
+	return &testSummary{packages: make(map[string]*packageTests)}
+}
+
+func (s *testSummary) pkg(path string) *packageTests {
+	p, ok := s.packages[path]
+	if !ok {
+		p = &packageTests{output: make(map[string][]string)}
+		s.packages[path] = p
+	}
+	return p
+}
+
+// add records the events of the output of 'go test -json', and returns
+// the number of events.
+func (s *testSummary) add(data []byte) (int, error) {
+	n := 0
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for dec.More() {
+		var ev testEvent
+		if err := dec.Decode(&ev); err != nil {
+			return n, fmt.Errorf("reading go test output: %v", err)
+		}
+		n++
+		if ev.Action == "build-output" {
+			// The package path may have a variant suffix: "p [p.test]".
+			path, _, _ := strings.Cut(ev.ImportPath, " ")
+			p := s.pkg(path)
+			p.buildOutput = append(p.buildOutput, strings.TrimSuffix(ev.Output, "\n"))
+			continue
+		}
+		if ev.Package == "" {
+			continue
+		}
+		p := s.pkg(ev.Package)
+		switch ev.Action {
+		case "output":
+			p.output[ev.Test] = append(p.output[ev.Test], strings.TrimSuffix(ev.Output, "\n"))
+		case "pass", "fail", "skip":
+			if ev.Test == "" {
+				p.result = ev.Action
+				break
+			}
+			if strings.Contains(ev.Test, "/") && ev.Action != "fail" {
+				break // only failed subtests are reported
+			}
+			switch ev.Action {
+			case "pass":
+				p.passed = append(p.passed, ev.Test)
+			case "fail":
+				p.failed = append(p.failed, ev.Test)
+			case "skip":
+				p.skipped = append(p.skipped, ev.Test)
+			}
+		}
+	}
+	return n, nil
+}
+
+// results returns the results of the tests of each package.
+func (s *testSummary) results() []testPackage {
+	var results []testPackage
+	for path, p := range moremaps.Sorted(s.packages) {
+		r := testPackage{Package: path}
+		switch {
+		case len(p.buildOutput) > 0:
+			r.Status = "build failed"
+			for _, line := range p.buildOutput {
+				if !strings.HasPrefix(line, "#") { // e.g. "# example.com/a"
+					r.BuildErrors = append(r.BuildErrors, line)
+				}
+			}
+		case p.result == "skip" || p.result == "pass" && len(p.passed)+len(p.skipped) == 0:
+			r.Status = "no tests"
+		case p.result == "pass":
+			r.Status = "ok"
+			r.Passed, r.Skipped = p.passed, p.skipped
+		default:
+			// The package failed.
+			r.Status = "fail"
+			r.Passed, r.Failed, r.Skipped = p.passed, p.failed, p.skipped
+			for _, test := range p.failed {
+				lines := failureLines(p.output[test])
+				if len(lines) == 0 && slices.ContainsFunc(p.failed, func(sub string) bool {
+					return strings.HasPrefix(sub, test+"/")
+				}) {
+					continue // the failure is reported by a subtest
+				}
+				r.Failures = append(r.Failures, testFailure{Test: test, Output: lines})
+			}
+			if len(p.failed) == 0 {
+				// e.g. a panic in TestMain, or a timeout.
+				if lines := failureLines(p.output[""]); len(lines) > 0 {
+					r.Failures = append(r.Failures, testFailure{Output: lines})
+				}
+			}
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+// writeTestResults writes the results of tests, in the style of 'go
+// test' output.
+func writeTestResults(w *strings.Builder, results []testPackage) {
+	for _, r := range results {
+		switch r.Status {
+		case "build failed":
+			fmt.Fprintf(w, "FAIL %s [build failed]\n", r.Package)
+			for _, line := range r.BuildErrors {
+				fmt.Fprintf(w, "    %s\n", line)
+			}
+		case "no tests":
+			fmt.Fprintf(w, "?    %s [no tests to run]\n", r.Package)
+		case "ok":
+			fmt.Fprintf(w, "ok   %s (%s)\n", r.Package, r.counts())
+		case "fail":
+			fmt.Fprintf(w, "FAIL %s (%s)\n", r.Package, r.counts())
+			for _, f := range r.Failures {
+				if f.Test != "" {
+					fmt.Fprintf(w, "--- FAIL: %s\n", f.Test)
+				}
+				for _, line := range f.Output {
+					fmt.Fprintf(w, "    %s\n", line)
+				}
+			}
+		}
+	}
+}
+
+// counts returns a description of the numbers of top-level tests that
+// passed, failed, and were skipped.
+func (r *testPackage) counts() string {
+	failed := 0
+	for _, test := range r.Failed {
+		if !strings.Contains(test, "/") {
+			failed++
+		}
+	}
+	counts := []string{fmt.Sprintf("%d passed", len(r.Passed))}
+	if failed > 0 {
+		counts = append(counts, fmt.Sprintf("%d failed", failed))
+	}
+	if len(r.Skipped) > 0 {
+		counts = append(counts, fmt.Sprintf("%d skipped", len(r.Skipped)))
+	}
+	return strings.Join(counts, ", ")
+}
+
+// assertionRx matches the start of a message logged by a test, such as
+// "    foo_test.go:12: got 1, want 2".
+var assertionRx = regexp.MustCompile(`^\s*[^\s:]+\.go:\d+: `)
+
+// failureLines returns the relevant lines of the output of a failed
+// test: the messages logged by the test (t.Error, t.Fatal, and so on),
+// including their continuation lines; or, in case of a panic, its
+// message and the stack frames outside the runtime and testing
+// packages; or else, the start of the output. The lines are unindented,
+// and at most maxFailureLines are returned.
+func failureLines(output []string) []string {
+	var all, logged, panicked []string
+	indent := -1 // indentation of the current logged message, if any
+	for i, line := range output {
+		trimmed := strings.TrimLeft(line, " \t")
+		switch {
+		case strings.HasPrefix(trimmed, "=== "), strings.HasPrefix(trimmed, "--- "):
+			indent = -1
+			continue // headers
+		case trimmed == "FAIL", trimmed == "PASS", strings.HasPrefix(trimmed, "exit status "),
+			strings.HasPrefix(trimmed, "FAIL\t"), strings.HasPrefix(trimmed, "ok  \t"):
+			continue // package summary
+		case strings.HasPrefix(trimmed, "panic: "):
+			msg, _, _ := strings.Cut(trimmed, " [recovered") // the suffix varies across Go versions
+			panicked = append(panicked, msg)
+			panicked = append(panicked, panicFrames(output[i+1:])...)
+		}
+		all = append(all, trimmed)
+		ind := len(line) - len(trimmed)
+		if assertionRx.MatchString(line) {
+			indent = ind
+			logged = append(logged, trimmed)
+		} else if indent >= 0 && ind > indent {
+			logged = append(logged, line[indent:])
+		} else {
+			indent = -1
+		}
+	}
+	lines := all
+	if len(logged) > 0 || len(panicked) > 0 {
+		lines = append(logged, panicked...)
+	}
+	if len(lines) > maxFailureLines {
+		more := len(lines) - maxFailureLines
+		lines = append(lines[:maxFailureLines:maxFailureLines], fmt.Sprintf("(%d more lines)", more))
+	}
+	return lines
+}
+
+// panicFrames returns the stack frames of the goroutine traceback that
+// follows a panic message, omitting those of the runtime and testing
+// packages, and the argument values and PC offsets, which vary.
+func panicFrames(output []string) []string {
+	var frames []string
+	for i := 0; i+1 < len(output); i++ {
+		fn, loc := strings.TrimSpace(output[i]), strings.TrimSpace(output[i+1])
+		if !strings.HasPrefix(output[i+1], "\t") || !strings.Contains(loc, ".go:") {
+			continue // not a frame
+		}
+		i++
+		if strings.HasPrefix(fn, "testing.") || strings.HasPrefix(fn, "runtime.") ||
+			strings.HasPrefix(fn, "panic(") || strings.HasPrefix(fn, "created by ") {
+			continue
+		}
+		if paren := strings.LastIndex(fn, "("); paren > 0 {
+			fn = fn[:paren] // strip arguments
+		}
+		loc, _, _ = strings.Cut(loc, " +0x")
+		frames = append(frames, fmt.Sprintf("%s at %s", fn, loc))
+	}
+	return frames
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/file"
+	"golang.org/x/tools/gopls/internal/golang"
+	"golang.org/x/tools/gopls/internal/protocol"
+)
+
+// typeHierarchyParams defines the parameters for the "go_type_hierarchy"
+// tool.
+type typeHierarchyParams struct {
+	File   string `json:"file" jsonschema:"the absolute path to the file containing the symbol"`
+	Symbol string `json:"symbol" jsonschema:"the symbol or qualified symbol of a type"`
+}
+
+type typeHierarchyResult struct {
+	Type       typeItem   `json:"type"`
+	Supertypes []typeItem `json:"supertypes" jsonschema:"the interfaces implemented by the type"`
+	Subtypes   []typeItem `json:"subtypes" jsonschema:"the types that implement the interface"`
+}
+
+// A typeItem is a type in a type hierarchy.
+type typeItem struct {
+	Name     string   `json:"name" jsonschema:"the package-qualified name of the type"`
+	Kind     string   `json:"kind" jsonschema:"the kind of the type, such as Interface, Struct, or Type"`
+	Location location `json:"location" jsonschema:"the location of the declaration of the type"`
+}
+
+// typeHierarchyHandler is the handler for the "go_type_hierarchy" tool.
+// It reports the supertypes (the interfaces a type implements) and
+// subtypes (the types that implement an interface) of the requested type.
+func (h *handler) typeHierarchyHandler(ctx context.Context, req *mcp.CallToolRequest, params typeHierarchyParams) (*mcp.CallToolResult, *typeHierarchyResult, error) {
+	countGoTypeHierarchyMCP.Inc()
+	fh, snapshot, release, err := h.fileOf(ctx, params.File)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer release()
+
+	if snapshot.FileKind(fh) != file.Go {
+		return nil, nil, fmt.Errorf("can't provide a type hierarchy for non-Go files")
+	}
+
+	loc, err := symbolLocation(ctx, snapshot, fh.URI(), params.Symbol)
+	if err != nil {
+		return nil, nil, err
+	}
+	declFH, err := snapshot.ReadFile(ctx, loc.URI)
+	if err != nil {
+		return nil, nil, err
+	}
+	items, err := golang.PrepareTypeHierarchy(ctx, snapshot, declFH, loc.Range)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %v", params.Symbol, err)
+	}
+	item := items[0]
+	supertypes, err := golang.Supertypes(ctx, snapshot, declFH, item)
+	if err != nil {
+		return nil, nil, err
+	}
+	subtypes, err := golang.Subtypes(ctx, snapshot, declFH, item)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var b strings.Builder
+	locator := newLocator(ctx, snapshot)
+	newItem := func(item protocol.TypeHierarchyItem) typeItem {
+		return typeItem{
+			Name:     item.Detail + "." + item.Name,
+			Kind:     kindName(item.Kind),
+			Location: locator.location(item.URI.Location(item.Range)),
+		}
+	}
+	result := &typeHierarchyResult{Type: newItem(item)}
+	fmt.Fprintf(&b, "Type: %s\n", formatTypeHierarchyItem(ctx, snapshot, item))
+	formatTypes := func(header string, items []protocol.TypeHierarchyItem) []typeItem {
+		fmt.Fprintf(&b, "\n%s:\n", header)
+		if len(items) == 0 {
+			b.WriteString("(none)\n")
+		}
+		var res []typeItem
+		for _, item := range items {
+			fmt.Fprintf(&b, "- %s\n", formatTypeHierarchyItem(ctx, snapshot, item))
+			res = append(res, newItem(item))
+		}
+		return res
+	}
+	result.Supertypes = formatTypes("Supertypes (interfaces implemented by the type)", supertypes)
+	result.Subtypes = formatTypes("Subtypes (types that implement the interface)", subtypes)
+	return textResult(b.String()), result, nil
+}
+
+// formatTypeHierarchyItem formats a type as its qualified name, kind,
+// and declaring location.
+func formatTypeHierarchyItem(ctx context.Context, snapshot *cache.Snapshot, item protocol.TypeHierarchyItem) string {
+	kind := "concrete type"
+	if item.Kind == protocol.Interface {
+		kind = "interface"
+	}
+	return fmt.Sprintf("%s.%s (%s) at %s", item.Detail, item.Name, kind, formatLocationLine(ctx, snapshot, item.URI.Location(item.Range)))
+}
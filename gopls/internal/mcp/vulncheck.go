@@ -41,8 +41,8 @@ func (h *handler) vulncheckHandler(ctx context.Context, req *mcp.CallToolRequest
 	defer release()
 
 	dir := params.Dir
-	if dir == "" && len(h.session.Views()) > 0 {
-		dir = h.session.Views()[0].Root().Path()
+	if dir == "" {
+		dir = snapshot.View().Root().Path()
 	}
 
 	pattern := params.Pattern
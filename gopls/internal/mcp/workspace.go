@@ -19,10 +19,31 @@ import (
 	"golang.org/x/tools/gopls/internal/util/immutable"
 )
 
-func (h *handler) workspaceHandler(ctx context.Context, req *mcp.CallToolRequest, _ any) (*mcp.CallToolResult, any, error) {
+type workspaceResult struct {
+	Builds []build `json:"builds"`
+}
+
+// A build is a directory of the workspace, and the way it is built.
+type build struct {
+	Dir     string   `json:"dir" jsonschema:"the absolute path to the root directory of the build"`
+	Type    string   `json:"type" jsonschema:"the type of the build: GoMod, GoWork, GOPATH, AdHoc (a package outside a module), or GoPackagesDriver (a custom build system)"`
+	GoWork  string   `json:"go_work,omitempty" jsonschema:"the absolute path to the go.work file, of a GoWork build"`
+	Modules []module `json:"modules,omitempty" jsonschema:"the main modules, of a GoMod or GoWork build"`
+}
+
+// A module is a main module of a build.
+type module struct {
+	GoMod string `json:"go_mod" jsonschema:"the absolute path to the go.mod file"`
+	Path  string `json:"path,omitempty" jsonschema:"the module path"`
+}
+
+func (h *handler) workspaceHandler(ctx context.Context, req *mcp.CallToolRequest, _ any) (*mcp.CallToolResult, *workspaceResult, error) {
 	countGoWorkspaceMCP.Inc()
-	var summary bytes.Buffer
-	views := h.session.Views()
+	var (
+		summary bytes.Buffer
+		result  workspaceResult
+	)
+	views := h.views()
 	for _, v := range views {
 		snapshot, release, err := v.Snapshot()
 		if err != nil {
@@ -43,6 +64,7 @@ func (h *handler) workspaceHandler(ctx context.Context, req *mcp.CallToolRequest
 		}
 
 		dir := v.Root().Path()
+		b := build{Dir: dir, Type: v.Type().String()}
 		switch v.Type() {
 		case cache.GoPackagesDriverView:
 			fmt.Fprintf(&summary, "The `%s` directory is loaded using a custom golang.org/x/tools/go/packages driver.\n", dir)
@@ -53,15 +75,17 @@ func (h *handler) workspaceHandler(ctx context.Context, req *mcp.CallToolRequest
 
 		case cache.GoModView:
 			fmt.Fprintf(&summary, "The `%s` directory uses Go modules, with the following main modules:\n", dir)
-			summarizeModFiles(ctx, &summary, snapshot)
+			b.Modules = summarizeModFiles(ctx, &summary, snapshot)
 
 		case cache.GoWorkView:
 			fmt.Fprintf(&summary, "The `%s` directory is in the go workspace defined by `%s`, with the following main modules:\n", dir, v.GoWork().Path())
-			summarizeModFiles(ctx, &summary, snapshot)
+			b.GoWork = v.GoWork().Path()
+			b.Modules = summarizeModFiles(ctx, &summary, snapshot)
 
 		case cache.AdHocView:
 			fmt.Fprintf(&summary, "The `%s` directory is an ad-hoc Go package, not in a Go module.\n", dir)
 		}
+		result.Builds = append(result.Builds, b)
 		fmt.Fprintln(&summary)
 		const summarizePackages = false
 		if summarizePackages {
@@ -71,19 +95,25 @@ func (h *handler) workspaceHandler(ctx context.Context, req *mcp.CallToolRequest
 			fmt.Fprintln(&summary)
 		}
 	}
-	return textResult(summary.String()), nil, nil
+	return textResult(summary.String()), &result, nil
 }
 
-func summarizeModFiles(ctx context.Context, w io.Writer, snapshot *cache.Snapshot) {
+// summarizeModFiles writes a summary of the main modules of the
+// snapshot to w, and returns them.
+func summarizeModFiles(ctx context.Context, w io.Writer, snapshot *cache.Snapshot) []module {
+	var modules []module
 	v := snapshot.View()
 	for _, m := range v.ModFiles() {
 		if modPath, err := modulePath(ctx, snapshot, m); err != nil {
 			// Fall back on just the go.mod file.
 			fmt.Fprintf(w, "\t%s\n", m.Path())
+			modules = append(modules, module{GoMod: m.Path()})
 		} else {
 			fmt.Fprintf(w, "\t%s (module %s)\n", m.Path(), modPath)
+			modules = append(modules, module{GoMod: m.Path(), Path: modPath})
 		}
 	}
+	return modules
 }
 
 func modulePath(ctx context.Context, snapshot *cache.Snapshot, uri protocol.DocumentURI) (string, error) {
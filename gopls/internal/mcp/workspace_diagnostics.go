@@ -20,7 +20,7 @@ type workspaceDiagnosticsParams struct {
 	Files []string `json:"files,omitempty" jsonschema:"absolute paths to active files, if any"`
 }
 
-func (h *handler) workspaceDiagnosticsHandler(ctx context.Context, req *mcp.CallToolRequest, params workspaceDiagnosticsParams) (*mcp.CallToolResult, any, error) {
+func (h *handler) workspaceDiagnosticsHandler(ctx context.Context, req *mcp.CallToolRequest, params workspaceDiagnosticsParams) (*mcp.CallToolResult, *diagnosticsResult, error) {
 	countGoDiagnosticsMCP.Inc()
 	var (
 		snapshot *cache.Snapshot
@@ -34,7 +34,7 @@ func (h *handler) workspaceDiagnosticsHandler(ctx context.Context, req *mcp.Call
 			return nil, nil, err
 		}
 	} else {
-		views := h.session.Views()
+		views := h.views()
 		if len(views) == 0 {
 			return nil, nil, fmt.Errorf("No active builds.")
 		}
@@ -62,14 +62,20 @@ func (h *handler) workspaceDiagnosticsHandler(ctx context.Context, req *mcp.Call
 		maps.Copy(fixes, fileFixes)
 	}
 
-	var b strings.Builder
+	var (
+		b      strings.Builder
+		result diagnosticsResult
+		loc    = newLocator(ctx, snapshot)
+	)
 	for _, uri := range slices.Sorted(maps.Keys(diagnostics)) {
 		diags := diagnostics[uri]
 		if len(diags) > 0 {
 			fmt.Fprintf(&b, "File `%s` has the following diagnostics:\n", uri.Path())
-			if err := summarizeDiagnostics(ctx, snapshot, &b, diags, fixes); err != nil {
+			summary, err := summarizeDiagnostics(ctx, snapshot, loc, &b, diags, fixes)
+			if err != nil {
 				return nil, nil, err
 			}
+			result.Diagnostics = append(result.Diagnostics, summary...)
 			fmt.Fprintln(&b)
 		}
 	}
@@ -77,5 +83,5 @@ func (h *handler) workspaceDiagnosticsHandler(ctx context.Context, req *mcp.Call
 		return textResult("No diagnostics."), nil, nil
 	}
 
-	return textResult(b.String()), nil, nil
+	return textResult(b.String()), &result, nil
 }
@@ -0,0 +1,157 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mod
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/internal/event"
+	"golang.org/x/tools/internal/gocommand"
+)
+
+// A Graph summarizes the module graph of a main module.
+type Graph struct {
+	Path    string               // the path of the main module
+	GoMod   protocol.DocumentURI // the go.mod file of the main module
+	Modules []*Module            // the build list, excluding the main module, sorted by path
+}
+
+// A Module describes a module of the build list of a main module.
+type Module struct {
+	Path       string
+	Version    string          // the version selected by minimal version selection
+	Required   string          // the version required by the go.mod file of the main module, if any
+	Direct     bool            // the main module requires the module directly, without "// indirect"
+	Replace    *module.Version // the replacement of the module, if any; a directory has no version
+	Update     string          // the newest version available, if known and newer than Version
+	RequiredBy []string        // the paths of the modules of the build list that require the module
+}
+
+// ModuleGraph returns the module graph of the main module of the
+// specified go.mod file, as selected by the go command without any
+// go.work file.
+//
+// If checkUpgrades is set, ModuleGraph queries the module proxy for the
+// available upgrades; otherwise, it reports the upgrades previously
+// found by the gopls.check_upgrades command, if any.
+func ModuleGraph(ctx context.Context, snapshot *cache.Snapshot, modURI protocol.DocumentURI, checkUpgrades bool) (*Graph, error) {
+	ctx, done := event.Start(ctx, "mod.ModuleGraph")
+	defer done()
+
+	fh, err := snapshot.ReadFile(ctx, modURI)
+	if err != nil {
+		return nil, err
+	}
+	pm, err := snapshot.ParseMod(ctx, fh)
+	if err != nil {
+		return nil, err
+	}
+	if pm.File == nil || pm.File.Module == nil {
+		return nil, fmt.Errorf("%s has no module statement", modURI.Path())
+	}
+
+	// Run the go command to compute the build list and its edges.
+	network := cache.NoNetwork
+	listArgs := []string{"-m", "-json", "all"}
+	if checkUpgrades {
+		network = cache.NetworkOK
+		listArgs = []string{"-m", "-u", "-json", "all"}
+	}
+	run := func(verb string, args []string) (*bytes.Buffer, error) {
+		inv, cleanup, err := snapshot.GoCommandInvocation(network, modURI.DirPath(), verb, args, "GOWORK=off")
+		if err != nil {
+			return nil, err
+		}
+		defer cleanup()
+		if verb == "list" {
+			inv.ModFlag = "readonly"
+		}
+		return snapshot.View().GoCommandRunner().Run(ctx, *inv)
+	}
+	list, err := run("list", listArgs)
+	if err != nil {
+		return nil, err
+	}
+	graph, err := run("mod", []string{"graph"})
+	if err != nil {
+		return nil, err
+	}
+
+	g := &Graph{Path: pm.File.Module.Mod.Path, GoMod: modURI}
+	byPath := make(map[string]*Module)
+	for dec := json.NewDecoder(list); dec.More(); {
+		var mod gocommand.ModuleJSON
+		if err := dec.Decode(&mod); err != nil {
+			return nil, err
+		}
+		if mod.Main {
+			continue
+		}
+		m := &Module{Path: mod.Path, Version: mod.Version}
+		if mod.Replace != nil {
+			m.Replace = &module.Version{Path: mod.Replace.Path, Version: mod.Replace.Version}
+		}
+		if mod.Update != nil {
+			m.Update = mod.Update.Version
+		}
+		g.Modules = append(g.Modules, m)
+		byPath[m.Path] = m
+	}
+	for _, req := range pm.File.Require {
+		if m, ok := byPath[req.Mod.Path]; ok {
+			m.Required = req.Mod.Version
+			m.Direct = !req.Indirect
+		}
+	}
+	if !checkUpgrades {
+		for path, version := range snapshot.ModuleUpgrades(modURI) {
+			if m, ok := byPath[path]; ok && semver.Compare(version, m.Version) > 0 {
+				m.Update = version
+			}
+		}
+	}
+
+	// Each line of the graph is an edge "from to", where each is a
+	// module path@version, or the path of the main module. Record only
+	// the edges from the versions of the build list.
+	selected := func(mv string) (string, bool) {
+		path, version, _ := strings.Cut(mv, "@")
+		if path == g.Path {
+			return path, version == ""
+		}
+		m, ok := byPath[path]
+		return path, ok && m.Version == version
+	}
+	for scan := bufio.NewScanner(graph); scan.Scan(); {
+		from, to, ok := strings.Cut(scan.Text(), " ")
+		if !ok {
+			continue
+		}
+		fromPath, ok := selected(from)
+		if !ok {
+			continue
+		}
+		toPath, _, _ := strings.Cut(to, "@")
+		if m, ok := byPath[toPath]; ok && !slices.Contains(m.RequiredBy, fromPath) {
+			m.RequiredBy = append(m.RequiredBy, fromPath)
+		}
+	}
+
+	slices.SortFunc(g.Modules, func(x, y *Module) int { return strings.Compare(x.Path, y.Path) })
+	for _, m := range g.Modules {
+		slices.Sort(m.RequiredBy)
+	}
+	return g, nil
+}
@@ -33,9 +33,11 @@ const (
 	ChangeSignature         Command = "gopls.change_signature"
 	CheckUpgrades           Command = "gopls.check_upgrades"
 	ClientOpenURL           Command = "gopls.client_open_url"
+	ConvertFunc             Command = "gopls.convert_func"
 	DiagnoseFiles           Command = "gopls.diagnose_files"
 	Doc                     Command = "gopls.doc"
 	EditGoDirective         Command = "gopls.edit_go_directive"
+	ExtractInterface        Command = "gopls.extract_interface"
 	ExtractToNewFile        Command = "gopls.extract_to_new_file"
 	FetchVulncheckResult    Command = "gopls.fetch_vulncheck_result"
 	FreeSymbols             Command = "gopls.free_symbols"
@@ -43,6 +45,7 @@ const (
 	Generate                Command = "gopls.generate"
 	GoGetPackage            Command = "gopls.go_get_package"
 	ImplementInterface      Command = "gopls.implement_interface"
+	IntroduceParamStruct    Command = "gopls.introduce_param_struct"
 	ListImports             Command = "gopls.list_imports"
 	ListKnownPackages       Command = "gopls.list_known_packages"
 	LSP                     Command = "gopls.lsp"
@@ -84,9 +87,11 @@ var Commands = []Command{
 	ChangeSignature,
 	CheckUpgrades,
 	ClientOpenURL,
+	ConvertFunc,
 	DiagnoseFiles,
 	Doc,
 	EditGoDirective,
+	ExtractInterface,
 	ExtractToNewFile,
 	FetchVulncheckResult,
 	FreeSymbols,
@@ -94,6 +99,7 @@ var Commands = []Command{
 	Generate,
 	GoGetPackage,
 	ImplementInterface,
+	IntroduceParamStruct,
 	ListImports,
 	ListKnownPackages,
 	LSP,
@@ -125,6 +131,16 @@ var Commands = []Command{
 	WorkspaceStats,
 }
 
+// ResolveEditsCommands lists the commands whose argument has a
+// ResolveEdits field. When it is set, they return their edits instead
+// of applying them using workspace/applyEdit.
+var ResolveEditsCommands = []Command{
+	ApplyFix,
+	ChangeSignature,
+	ConvertFunc,
+	IntroduceParamStruct,
+}
+
 func Dispatch(ctx context.Context, params *protocol.ExecuteCommandParams, s Interface) (any, error) {
 	switch Command(params.Command) {
 	case AddDependency:
@@ -170,7 +186,7 @@ func Dispatch(ctx context.Context, params *protocol.ExecuteCommandParams, s Inte
 		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
 			return nil, err
 		}
-		return s.ChangeSignature(ctx, a0)
+		return s.ChangeSignature(ctx, a0, &params.InteractiveParams)
 	case CheckUpgrades:
 		var a0 CheckUpgradesArgs
 		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
@@ -183,6 +199,12 @@ func Dispatch(ctx context.Context, params *protocol.ExecuteCommandParams, s Inte
 			return nil, err
 		}
 		return nil, s.ClientOpenURL(ctx, a0)
+	case ConvertFunc:
+		var a0 ConvertFuncArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return s.ConvertFunc(ctx, a0)
 	case DiagnoseFiles:
 		var a0 DiagnoseFilesArgs
 		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
@@ -201,6 +223,12 @@ func Dispatch(ctx context.Context, params *protocol.ExecuteCommandParams, s Inte
 			return nil, err
 		}
 		return nil, s.EditGoDirective(ctx, a0)
+	case ExtractInterface:
+		var a0 ExtractInterfaceArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return nil, s.ExtractInterface(ctx, a0, &params.InteractiveParams)
 	case ExtractToNewFile:
 		var a0 protocol.Location
 		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
@@ -244,6 +272,12 @@ func Dispatch(ctx context.Context, params *protocol.ExecuteCommandParams, s Inte
 			return nil, err
 		}
 		return nil, s.ImplementInterface(ctx, a0, &params.InteractiveParams)
+	case IntroduceParamStruct:
+		var a0 IntroduceParamStructArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return s.IntroduceParamStruct(ctx, a0)
 	case ListImports:
 		var a0 URIArg
 		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
@@ -475,6 +509,14 @@ func NewClientOpenURLCommand(title string, a0 string) *protocol.Command {
 	}
 }
 
+func NewConvertFuncCommand(title string, a0 ConvertFuncArgs) *protocol.Command {
+	return &protocol.Command{
+		Title:     title,
+		Command:   ConvertFunc.String(),
+		Arguments: MustMarshalArgs(a0),
+	}
+}
+
 func NewDiagnoseFilesCommand(title string, a0 DiagnoseFilesArgs) *protocol.Command {
 	return &protocol.Command{
 		Title:     title,
@@ -499,6 +541,14 @@ func NewEditGoDirectiveCommand(title string, a0 EditGoDirectiveArgs) *protocol.C
 	}
 }
 
+func NewExtractInterfaceCommand(title string, a0 ExtractInterfaceArgs) *protocol.Command {
+	return &protocol.Command{
+		Title:     title,
+		Command:   ExtractInterface.String(),
+		Arguments: MustMarshalArgs(a0),
+	}
+}
+
 func NewExtractToNewFileCommand(title string, a0 protocol.Location) *protocol.Command {
 	return &protocol.Command{
 		Title:     title,
@@ -555,6 +605,14 @@ func NewImplementInterfaceCommand(title string, a0 ImplementInterfaceArgs) *prot
 	}
 }
 
+func NewIntroduceParamStructCommand(title string, a0 IntroduceParamStructArgs) *protocol.Command {
+	return &protocol.Command{
+		Title:     title,
+		Command:   IntroduceParamStruct.String(),
+		Arguments: MustMarshalArgs(a0),
+	}
+}
+
 func NewListImportsCommand(title string, a0 URIArg) *protocol.Command {
 	return &protocol.Command{
 		Title:     title,
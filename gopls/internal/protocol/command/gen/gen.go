@@ -54,6 +54,17 @@ var Commands = []Command {
 {{- end}}
 }
 
+// ResolveEditsCommands lists the commands whose argument has a
+// ResolveEdits field. When it is set, they return their edits instead
+// of applying them using workspace/applyEdit.
+var ResolveEditsCommands = []Command {
+{{- range .Commands}}
+	{{- if resolvesEdits .Args}}
+	{{.MethodName}},
+	{{- end}}
+{{- end}}
+}
+
 func Dispatch(ctx context.Context, params *protocol.ExecuteCommandParams, s Interface) (any, error) {
 	switch Command(params.Command) {
 	{{- range .Commands}}
@@ -121,6 +132,21 @@ func Generate() ([]byte, error) {
 		"typeString": func(t types.Type) string {
 			return types.TypeString(t, qual)
 		},
+		"resolvesEdits": func(args []*commandmeta.Field) bool {
+			if len(args) == 0 {
+				return false
+			}
+			s, ok := args[0].Type.Underlying().(*types.Struct)
+			if !ok {
+				return false
+			}
+			for field := range s.Fields() {
+				if field.Name() == "ResolveEdits" {
+					return true
+				}
+			}
+			return false
+		},
 		"fallible": func(args []*commandmeta.Field) bool {
 			var fallible func(types.Type) bool
 			fallible = func(t types.Type) bool {
@@ -268,9 +268,28 @@ type Interface interface {
 
 	// ChangeSignature: Perform a "change signature" refactoring
 	//
-	// This command is experimental, currently only supporting parameter removal.
-	// Its signature will certainly change in the future (pun intended).
-	ChangeSignature(context.Context, ChangeSignatureArgs) (*protocol.WorkspaceEdit, error)
+	// This command is experimental, currently only supporting the removal,
+	// reordering, and addition of parameters. Its signature will certainly
+	// change in the future (pun intended).
+	//
+	// If the client supports interactive forms, and the new signature adds
+	// exactly one parameter, the client may ask the user for the parameter
+	// and its argument at existing calls.
+	ChangeSignature(context.Context, ChangeSignatureArgs, *protocol.InteractiveParams) (*protocol.WorkspaceEdit, error)
+
+	// ConvertFunc: Convert a function to a method, or vice versa
+	//
+	// Converts a function to a method of the type of its first
+	// parameter, or a method to a function whose first parameter is the
+	// receiver, and updates all references to it in the workspace.
+	ConvertFunc(context.Context, ConvertFuncArgs) (*protocol.WorkspaceEdit, error)
+
+	// IntroduceParamStruct: Collect parameters into a struct
+	//
+	// Replaces the selected parameters of a function by a parameter of
+	// a new struct type with the corresponding fields, and updates all
+	// calls of the function to pass a struct literal.
+	IntroduceParamStruct(context.Context, IntroduceParamStructArgs) (*protocol.WorkspaceEdit, error)
 
 	// DiagnoseFiles: Cause server to publish diagnostics for the specified files.
 	//
@@ -337,6 +356,14 @@ type Interface interface {
 	// ImplementInterface: Add methods to a type to implement an interface.
 	ImplementInterface(context.Context, ImplementInterfaceArgs, *protocol.InteractiveParams) error
 
+	// ExtractInterface: Declare an interface with the methods of a type.
+	//
+	// The interactive form specifies the name of the interface, the
+	// methods it contains, the package in which to declare it, and
+	// optionally a function whose parameters of the concrete type should
+	// be changed to accept the interface instead.
+	ExtractInterface(context.Context, ExtractInterfaceArgs, *protocol.InteractiveParams) error
+
 	// MoveDeclaration: Move a declaration to a different file.
 	MoveDeclaration(context.Context, MoveDeclarationArgs, *protocol.InteractiveParams) error
 }
@@ -626,12 +653,18 @@ type AddTelemetryCountersArgs struct {
 //     old signature.
 //   - If the element is a string, it is parsed as a new field to add.
 //
+// A new parameter field has the form "name type", or "name type = expr",
+// where expr is the argument for the new parameter at existing calls. If it
+// is omitted, the argument is the zero value of the parameter type.
+//
 // Suppose we have a function `F(a, b int) (string, error)`. Here are some
 // examples of refactoring this signature in practice, eliding the 'Location'
 // and 'ResolveEdits' fields.
 //   - `{ "NewParams": [0], "NewResults": [0, 1] }` removes the second parameter
 //   - `{ "NewParams": [1, 0], "NewResults": [0, 1] }` flips the parameter order
 //   - `{ "NewParams": [0, 1, "a int"], "NewResults": [0, 1] }` adds a new field
+//   - `{ "NewParams": ["ctx context.Context = context.TODO()", 0, 1], "NewResults": [0, 1] }`
+//     adds a new first parameter, passing context.TODO() at existing calls
 //   - `{ "NewParams": [1, 2], "NewResults": [1] }` drops the `error` result
 type ChangeSignatureArgs struct {
 	// Location is any range inside the function signature. By convention, this
@@ -640,7 +673,8 @@ type ChangeSignatureArgs struct {
 
 	// NewParams describes parameters of the new signature.
 	// An int value references a parameter in the old signature by index.
-	// A string value describes a new parameter field (e.g. "x int").
+	// A string value describes a new parameter field (e.g. "x int"),
+	// optionally followed by its argument at existing calls (e.g. "x int = 1").
 	NewParams []ChangeSignatureParam
 
 	// NewResults describes results of the new signature (see above).
@@ -680,6 +714,27 @@ func (a ChangeSignatureParam) MarshalJSON() ([]byte, error) {
 	return json.Marshal(a.OldIndex)
 }
 
+// ConvertFuncArgs specifies a function or method to convert.
+type ConvertFuncArgs struct {
+	// Location is any range inside the header of the function or
+	// method declaration, as passed to CodeAction.
+	Location protocol.Location
+
+	// Whether to resolve and return the edits.
+	ResolveEdits bool
+}
+
+// IntroduceParamStructArgs specifies the parameters to collect into a
+// struct.
+type IntroduceParamStructArgs struct {
+	// Location is the range of the selected parameters, as passed to
+	// CodeAction.
+	Location protocol.Location
+
+	// Whether to resolve and return the edits.
+	ResolveEdits bool
+}
+
 // DiagnoseFilesArgs specifies a set of files for which diagnostics are wanted.
 type DiagnoseFilesArgs struct {
 	Files []protocol.DocumentURI
@@ -884,6 +939,24 @@ type ImplementInterfaceArgs struct {
 	Interface string
 }
 
+// ExtractInterfaceArgs holds the arguments to the ExtractInterface
+// command: the type from which to extract an interface, and the
+// defaults of the form that asks for the details of the interface.
+type ExtractInterfaceArgs struct {
+	// Location is the location where the user invoked the code action.
+	// This location must be within a type declaration.
+	Location protocol.Location
+
+	// The remaining fields are the defaults proposed by the form.
+
+	// Name is the name of the interface.
+	Name string
+	// Methods are the names of the exported methods of the type.
+	Methods []string
+	// Package is the path of the package that declares the type.
+	Package string
+}
+
 // ModifyTagsArgs holds variables that determine how struct tags are modified.
 type ModifyTagsArgs struct {
 	// NOTE(hxjiang): the mofidication field is important, when resolving a
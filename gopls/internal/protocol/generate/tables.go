@@ -159,7 +159,7 @@ var goplsType = map[string]string{
 	"Or_Result_textDocument_implementation":            "[]Location",
 	"Or_Result_textDocument_semanticTokens_full_delta": "any",
 	"Or_Result_textDocument_typeDefinition":            "[]Location",
-	"Or_Result_workspace_symbol":                       "[]SymbolInformation",
+	"Or_Result_workspace_symbol":                       "[]WorkspaceSymbol",
 	"Or_TextDocumentContentChangeEvent":                "TextDocumentContentChangePartial",
 	"Or_RelativePattern_baseUri":                       "DocumentURI",
 
@@ -185,7 +185,7 @@ type Server interface {
 	// See https://microsoft.github.io/language-server-protocol/specifications/lsp/3.18/specification#workspace_executeCommand
 	ExecuteCommand(context.Context, *ExecuteCommandParams) (any, error)
 	// See https://microsoft.github.io/language-server-protocol/specifications/lsp/3.18/specification#workspace_symbol
-	Symbol(context.Context, *WorkspaceSymbolParams) ([]SymbolInformation, error)
+	Symbol(context.Context, *WorkspaceSymbolParams) ([]WorkspaceSymbol, error)
 	// See https://microsoft.github.io/language-server-protocol/specifications/lsp/3.18/specification#workspace_textDocumentContent
 	TextDocumentContent(context.Context, *TextDocumentContentParams) (*TextDocumentContentResult, error)
 	// See https://microsoft.github.io/language-server-protocol/specifications/lsp/3.18/specification#workspace_willCreateFiles
@@ -1517,8 +1517,8 @@ func (s *serverDispatcher) ExecuteCommand(ctx context.Context, params *ExecuteCo
 	}
 	return result, nil
 }
-func (s *serverDispatcher) Symbol(ctx context.Context, params *WorkspaceSymbolParams) ([]SymbolInformation, error) {
-	var result []SymbolInformation
+func (s *serverDispatcher) Symbol(ctx context.Context, params *WorkspaceSymbolParams) ([]WorkspaceSymbol, error) {
+	var result []WorkspaceSymbol
 	if err := s.sender.Call(ctx, "workspace/symbol", params, &result); err != nil {
 		return nil, err
 	}
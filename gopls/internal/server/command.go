@@ -1637,7 +1637,7 @@ func showDocumentImpl(ctx context.Context, cli protocol.Client, url protocol.URI
 	}
 }
 
-func (c *commandHandler) ChangeSignature(ctx context.Context, args command.ChangeSignatureArgs) (*protocol.WorkspaceEdit, error) {
+func (c *commandHandler) ChangeSignature(ctx context.Context, args command.ChangeSignatureArgs, params *protocol.InteractiveParams) (*protocol.WorkspaceEdit, error) {
 	countChangeSignature.Inc()
 	var result *protocol.WorkspaceEdit
 	err := c.run(ctx, commandConfig{
@@ -1648,16 +1648,68 @@ func (c *commandHandler) ChangeSignature(ctx context.Context, args command.Chang
 			return err
 		}
 
-		// For now, gopls only supports parameter permutation or removal.
-		var perm []int
-		for _, newParam := range args.NewParams {
-			if newParam.NewField != "" {
-				return fmt.Errorf("adding new parameters is currently unsupported")
+		// The form answers, if any, describe the single added parameter.
+		if len(params.FormAnswers) > 0 {
+			field, err := golang.AddedParamAnswer(params)
+			if err != nil {
+				return err
+			}
+			for i, newParam := range args.NewParams {
+				if newParam.NewField != "" {
+					args.NewParams[i].NewField = field
+				}
 			}
-			perm = append(perm, newParam.OldIndex)
 		}
 
-		docedits, err := golang.ChangeSignature(ctx, deps.snapshot, pkg, pgf, args.Location.Range, perm)
+		docedits, err := golang.ChangeSignature(ctx, deps.snapshot, pkg, pgf, args.Location.Range, args.NewParams)
+		if err != nil {
+			return err
+		}
+		wsedit := protocol.NewWorkspaceEdit(docedits...)
+		if args.ResolveEdits {
+			result = wsedit
+			return nil
+		}
+		return applyChanges(ctx, c.s.client, docedits)
+	})
+	return result, err
+}
+
+func (c *commandHandler) ConvertFunc(ctx context.Context, args command.ConvertFuncArgs) (*protocol.WorkspaceEdit, error) {
+	countConvertFunc.Inc()
+	var result *protocol.WorkspaceEdit
+	err := c.run(ctx, commandConfig{
+		forURI: args.Location.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		pkg, pgf, err := golang.NarrowestPackageForFile(ctx, deps.snapshot, args.Location.URI)
+		if err != nil {
+			return err
+		}
+		docedits, err := golang.ConvertFunc(ctx, deps.snapshot, pkg, pgf, args.Location.Range)
+		if err != nil {
+			return err
+		}
+		wsedit := protocol.NewWorkspaceEdit(docedits...)
+		if args.ResolveEdits {
+			result = wsedit
+			return nil
+		}
+		return applyChanges(ctx, c.s.client, docedits)
+	})
+	return result, err
+}
+
+func (c *commandHandler) IntroduceParamStruct(ctx context.Context, args command.IntroduceParamStructArgs) (*protocol.WorkspaceEdit, error) {
+	countParamStruct.Inc()
+	var result *protocol.WorkspaceEdit
+	err := c.run(ctx, commandConfig{
+		forURI: args.Location.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		pkg, pgf, err := golang.NarrowestPackageForFile(ctx, deps.snapshot, args.Location.URI)
+		if err != nil {
+			return err
+		}
+		docedits, err := golang.IntroduceParamStruct(ctx, deps.snapshot, pkg, pgf, args.Location.Range)
 		if err != nil {
 			return err
 		}
@@ -1832,6 +1884,23 @@ func (c *commandHandler) ImplementInterface(ctx context.Context, args command.Im
 	})
 }
 
+func (c *commandHandler) ExtractInterface(ctx context.Context, args command.ExtractInterfaceArgs, params *protocol.InteractiveParams) error {
+	return c.run(ctx, commandConfig{
+		progress: "Extract interface",
+		forURI:   args.Location.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		x, err := golang.ExtractInterfaceAnswer(params)
+		if err != nil {
+			return err
+		}
+		edits, err := golang.ExtractInterface(ctx, deps.snapshot, args.Location, x)
+		if err != nil {
+			return err
+		}
+		return applyChanges(ctx, c.s.client, edits)
+	})
+}
+
 func (c *commandHandler) ModifyTags(ctx context.Context, args command.ModifyTagsArgs, params *protocol.InteractiveParams) error {
 	return c.run(ctx, commandConfig{
 		progress: "Modifying tags",
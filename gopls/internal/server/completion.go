@@ -6,6 +6,7 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -147,19 +148,6 @@ func toProtocolCompletionItems(candidates []completion.CompletionItem, surroundi
 			continue
 		}
 
-		var doc *protocol.Or_CompletionItem_documentation
-		if candidate.Documentation != "" {
-			var value any
-			if options.PreferredContentFormat == protocol.Markdown {
-				value = protocol.MarkupContent{
-					Kind:  protocol.Markdown,
-					Value: golang.DocCommentToMarkdown(candidate.Documentation, options),
-				}
-			} else {
-				value = candidate.Documentation
-			}
-			doc = &protocol.Or_CompletionItem_documentation{Value: value}
-		}
 		var edits *protocol.Or_CompletionItem_textEdit
 		if options.InsertReplaceSupported {
 			insertRng := insertRng0
@@ -202,11 +190,79 @@ func toProtocolCompletionItems(candidates []completion.CompletionItem, surroundi
 			FilterText: strings.TrimLeft(candidate.InsertText, "&*"),
 
 			Preselect:     i == 0,
-			Documentation: doc,
+			Documentation: completionDocumentation(candidate.Documentation, options),
 			Tags:          protocol.NonNilSlice(candidate.Tags),
 			Deprecated:    candidate.Deprecated,
 		}
+		if candidate.Resolve != nil {
+			item.Data = candidate.Resolve
+		}
 		items = append(items, item)
 	}
 	return items, nil
 }
+
+// completionDocumentation converts the documentation of a completion
+// candidate to the client's preferred format. It returns nil if there
+// is no documentation.
+func completionDocumentation(doc string, options *settings.Options) *protocol.Or_CompletionItem_documentation {
+	if doc == "" {
+		return nil
+	}
+	var value any
+	if options.PreferredContentFormat == protocol.Markdown {
+		value = protocol.MarkupContent{
+			Kind:  protocol.Markdown,
+			Value: golang.DocCommentToMarkdown(doc, options),
+		}
+	} else {
+		value = doc
+	}
+	return &protocol.Or_CompletionItem_documentation{Value: value}
+}
+
+// ResolveCompletionItem computes the documentation and import edits of
+// a completion item that were deferred by the completion request, as
+// described by the item's data. See [completion.ResolveData].
+func (s *server) ResolveCompletionItem(ctx context.Context, item *protocol.CompletionItem) (*protocol.CompletionItem, error) {
+	ctx, done := event.Start(ctx, "server.ResolveCompletionItem")
+	defer done()
+
+	if item.Data == nil {
+		return item, nil // nothing to resolve
+	}
+	// The client sends back the data as arbitrary JSON.
+	raw, err := json.Marshal(item.Data)
+	if err != nil {
+		return nil, err
+	}
+	var data completion.ResolveData
+	if err := protocol.UnmarshalJSON(raw, &data); err != nil {
+		return nil, fmt.Errorf("unmarshalling completion item data: %w", err)
+	}
+
+	fh, snapshot, release, err := s.session.FileOf(ctx, data.URI)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if snapshot.FileKind(fh) != file.Go {
+		return item, nil
+	}
+	resolved, err := completion.Resolve(ctx, snapshot, &data)
+	if err != nil {
+		return nil, err
+	}
+	options := snapshot.Options()
+	if doc := completionDocumentation(resolved.Documentation, options); doc != nil {
+		item.Documentation = doc
+	}
+	if len(resolved.Tags) > 0 {
+		item.Tags = resolved.Tags
+	}
+	item.Deprecated = item.Deprecated || resolved.Deprecated
+	item.AdditionalTextEdits = append(item.AdditionalTextEdits, resolved.AdditionalTextEdits...)
+	item.Data = nil
+	return item, nil
+}
@@ -27,12 +27,17 @@ var (
 	}
 )
 
-// Proposed counters for evaluating gopls change signature and rename. These
-// counters increment when the user attempts to perform one of these operations,
-// regardless of whether it succeeds.
+// Proposed counters for evaluating gopls change signature, function
+// conversion, parameter structs, and rename. These counters increment when
+// the user attempts to perform one of these operations, regardless of
+// whether it succeeds.
 var (
 	countChangeSignature = counter.New("gopls/changesig")
 
+	countConvertFunc = counter.New("gopls/convertfunc")
+
+	countParamStruct = counter.New("gopls/paramstruct")
+
 	countRename = counter.New("gopls/rename")
 )
 
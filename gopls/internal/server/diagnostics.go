@@ -76,6 +76,153 @@ func (s *server) Diagnostic(ctx context.Context, params *protocol.DocumentDiagno
 	}, nil
 }
 
+// DiagnosticWorkspace implements the workspace/diagnostic LSP request,
+// reporting diagnostics for all files of the workspace.
+//
+// The result ID of each file report has two parts: a token for the
+// state of the workspace, derived from the ID and snapshot sequence
+// number of each view, and the hash of the file's set of diagnostics.
+// If the token of every result ID the client provided in the request
+// is current, nothing has changed since the previous request, so every
+// file is reported as "unchanged" without diagnosing the workspace.
+// Otherwise, a file whose diagnostics are unchanged since its previous
+// result ID is reported as "unchanged", without its items.
+func (s *server) DiagnosticWorkspace(ctx context.Context, params *protocol.WorkspaceDiagnosticParams) (*protocol.WorkspaceDiagnosticReport, error) {
+	ctx, done := event.Start(ctx, "server.DiagnosticWorkspace")
+	defer done()
+
+	jsonrpc2.Async(ctx) // allow asynchronous collection of diagnostics
+
+	var (
+		snapshots []*cache.Snapshot
+		state     []byte // the IDs and snapshot sequence numbers of all views
+	)
+	for _, view := range s.session.Views() {
+		snapshot, release, err := view.Snapshot()
+		if err != nil {
+			continue // view was shut down
+		}
+		defer release()
+		snapshots = append(snapshots, snapshot)
+		state = fmt.Appendf(state, "%s:%d\n", view.ID(), snapshot.SequenceID())
+	}
+	token := file.HashOf(state).String()
+
+	report := &protocol.WorkspaceDiagnosticReport{
+		Items: []protocol.WorkspaceDocumentDiagnosticReport{}, // non-nil
+	}
+	unchanged := func(uri protocol.DocumentURI, version int32, resultID string) {
+		report.Items = append(report.Items, protocol.WorkspaceDocumentDiagnosticReport{
+			Value: protocol.WorkspaceUnchangedDocumentDiagnosticReport{
+				URI:     uri,
+				Version: version,
+				UnchangedDocumentDiagnosticReport: protocol.UnchangedDocumentDiagnosticReport{
+					Kind:     string(protocol.DiagnosticUnchanged),
+					ResultID: resultID,
+				},
+			},
+		})
+	}
+
+	previous := make(map[protocol.DocumentURI]string)
+	current := len(params.PreviousResultIds) > 0 && len(snapshots) > 0
+	for _, prev := range params.PreviousResultIds {
+		previous[prev.URI] = prev.Value
+		if tok, _, _ := strings.Cut(prev.Value, "-"); tok != token {
+			current = false
+		}
+	}
+	if current {
+		for _, uri := range moremaps.KeySlice(previous) {
+			fh, err := snapshots[0].ReadFile(ctx, uri) // overlays are shared by all views
+			if err != nil {
+				return nil, err
+			}
+			unchanged(uri, fh.Version(), previous[uri])
+		}
+		return report, nil
+	}
+
+	// workspaceFileDiagnostics accumulates the diagnostics of a
+	// file, de-duplicated across views.
+	type workspaceFileDiagnostics struct {
+		version     int32
+		hash        file.Hash
+		seen        map[file.Hash]bool
+		diagnostics []*cache.Diagnostic
+	}
+	files := make(map[protocol.DocumentURI]*workspaceFileDiagnostics)
+	add := func(snapshot *cache.Snapshot, uri protocol.DocumentURI, diags []*cache.Diagnostic) error {
+		f, ok := files[uri]
+		if !ok {
+			fh, err := snapshot.ReadFile(ctx, uri)
+			if err != nil {
+				return err
+			}
+			f = &workspaceFileDiagnostics{
+				version: fh.Version(),
+				seen:    make(map[file.Hash]bool),
+			}
+			files[uri] = f
+		}
+		for _, diag := range diags {
+			if h := diag.Hash(); !f.seen[h] {
+				f.seen[h] = true
+				f.hash.XORWith(h)
+				f.diagnostics = append(f.diagnostics, diag)
+			}
+		}
+		return nil
+	}
+
+	for _, snapshot := range snapshots {
+		diagnostics, err := s.diagnose(ctx, snapshot)
+		if err != nil {
+			return nil, err
+		}
+		// Report every file of the workspace, even those without
+		// diagnostics, so that the client can clear stale ones.
+		workspacePkgs, err := snapshot.WorkspaceMetadata(ctx)
+		if err != nil && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		for _, mp := range workspacePkgs {
+			for _, uri := range mp.CompiledGoFiles {
+				if _, ok := diagnostics[uri]; !ok && !snapshot.IgnoredFile(uri) {
+					diagnostics[uri] = nil
+				}
+			}
+		}
+		for uri, diags := range diagnostics {
+			if err := add(snapshot, uri, diags); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, uri := range moremaps.KeySlice(files) {
+		f := files[uri]
+		resultID := token + "-" + f.hash.String()
+		if _, hash, _ := strings.Cut(previous[uri], "-"); hash == f.hash.String() {
+			unchanged(uri, f.version, resultID)
+			continue
+		}
+		sortDiagnostics(f.diagnostics)
+		report.Items = append(report.Items, protocol.WorkspaceDocumentDiagnosticReport{
+			Value: protocol.WorkspaceFullDocumentDiagnosticReport{
+				URI:     uri,
+				Version: f.version,
+				FullDocumentDiagnosticReport: protocol.FullDocumentDiagnosticReport{
+					Kind:     string(protocol.DiagnosticFull),
+					ResultID: resultID,
+					Items:    cache.ToProtocolDiagnostics(f.diagnostics...),
+				},
+			},
+		})
+	}
+	return report, nil
+}
+
 // fileDiagnostics holds the current state of published diagnostics for a file.
 type fileDiagnostics struct {
 	publishedHash file.Hash // hash of the last set of diagnostics published for this URI
@@ -36,3 +36,48 @@ func (s *server) Formatting(ctx context.Context, params *protocol.DocumentFormat
 	}
 	return nil, nil // empty result
 }
+
+func (s *server) RangeFormatting(ctx context.Context, params *protocol.DocumentRangeFormattingParams) ([]protocol.TextEdit, error) {
+	ctx, done := event.Start(ctx, "server.RangeFormatting", label.URI.Of(params.TextDocument.URI))
+	defer done()
+
+	return s.formatRanges(ctx, params.TextDocument.URI, []protocol.Range{params.Range})
+}
+
+func (s *server) RangesFormatting(ctx context.Context, params *protocol.DocumentRangesFormattingParams) ([]protocol.TextEdit, error) {
+	ctx, done := event.Start(ctx, "server.RangesFormatting", label.URI.Of(params.TextDocument.URI))
+	defer done()
+
+	return s.formatRanges(ctx, params.TextDocument.URI, params.Ranges)
+}
+
+func (s *server) OnTypeFormatting(ctx context.Context, params *protocol.DocumentOnTypeFormattingParams) ([]protocol.TextEdit, error) {
+	ctx, done := event.Start(ctx, "server.OnTypeFormatting", label.URI.Of(params.TextDocument.URI))
+	defer done()
+
+	fh, snapshot, release, err := s.session.FileOf(ctx, params.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	switch snapshot.FileKind(fh) {
+	case file.Go:
+		return golang.FormatOnType(ctx, snapshot, fh, params.Position, params.Ch)
+	}
+	return nil, nil // empty result
+}
+
+func (s *server) formatRanges(ctx context.Context, uri protocol.DocumentURI, rngs []protocol.Range) ([]protocol.TextEdit, error) {
+	fh, snapshot, release, err := s.session.FileOf(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	switch snapshot.FileKind(fh) {
+	case file.Go:
+		return golang.FormatRanges(ctx, snapshot, fh, rngs)
+	}
+	return nil, nil // empty result
+}
@@ -113,7 +113,7 @@ func (s *server) Initialize(ctx context.Context, params *protocol.ParamInitializ
 		diagnosticProvider = &protocol.Or_ServerCapabilities_diagnosticProvider{
 			Value: protocol.DiagnosticOptions{
 				InterFileDependencies: true,
-				WorkspaceDiagnostics:  false, // we don't support workspace/diagnostic
+				WorkspaceDiagnostics:  true,
 			},
 		}
 	}
@@ -175,7 +175,7 @@ func (s *server) Initialize(ctx context.Context, params *protocol.ParamInitializ
 		// gopls settings at that point allow us to return them.
 		semanticTokenProvider = protocol.SemanticTokensOptions{
 			Range: &protocol.Or_SemanticTokensOptions_range{Value: true},
-			Full:  &protocol.Or_SemanticTokensOptions_full{Value: true},
+			Full:  &protocol.Or_SemanticTokensOptions_full{Value: protocol.SemanticTokensFullDelta{Delta: true}},
 			Legend: protocol.SemanticTokensLegend{
 				TokenTypes:     moreslices.ConvertStrings[string](semtok.Types),
 				TokenModifiers: moreslices.ConvertStrings[string](semtok.Modifiers),
@@ -189,6 +189,8 @@ func (s *server) Initialize(ctx context.Context, params *protocol.ParamInitializ
 		// is a setting that should ideally live on the front-end.
 	}
 
+	folderPattern := protocol.FolderPattern
+
 	versionInfo := debug.VersionInfo()
 
 	goplsVersion, err := json.Marshal(versionInfo)
@@ -203,26 +205,37 @@ func (s *server) Initialize(ctx context.Context, params *protocol.ParamInitializ
 			CodeLensProvider:      &protocol.CodeLensOptions{}, // must be non-nil to enable the code lens capability
 			CompletionProvider: &protocol.CompletionOptions{
 				TriggerCharacters: []string{"."},
+				ResolveProvider:   true,
 			},
 			DefinitionProvider:         &protocol.Or_ServerCapabilities_definitionProvider{Value: true},
 			TypeDefinitionProvider:     &protocol.Or_ServerCapabilities_typeDefinitionProvider{Value: true},
 			ImplementationProvider:     &protocol.Or_ServerCapabilities_implementationProvider{Value: true},
 			DocumentFormattingProvider: &protocol.Or_ServerCapabilities_documentFormattingProvider{Value: true},
-			DocumentSymbolProvider:     &protocol.Or_ServerCapabilities_documentSymbolProvider{Value: true},
-			WorkspaceSymbolProvider:    &protocol.Or_ServerCapabilities_workspaceSymbolProvider{Value: true},
+			DocumentRangeFormattingProvider: &protocol.Or_ServerCapabilities_documentRangeFormattingProvider{
+				Value: protocol.DocumentRangeFormattingOptions{RangesSupport: true},
+			},
+			DocumentOnTypeFormattingProvider: &protocol.DocumentOnTypeFormattingOptions{
+				FirstTriggerCharacter: "\n",
+				MoreTriggerCharacter:  []string{"}", ";"},
+			},
+			DocumentSymbolProvider:  &protocol.Or_ServerCapabilities_documentSymbolProvider{Value: true},
+			WorkspaceSymbolProvider: &protocol.Or_ServerCapabilities_workspaceSymbolProvider{Value: protocol.WorkspaceSymbolOptions{ResolveProvider: true}},
 			ExecuteCommandProvider: &protocol.ExecuteCommandOptions{
 				Commands: protocol.NonNilSlice(options.SupportedCommands),
 			},
-			FoldingRangeProvider:      &protocol.Or_ServerCapabilities_foldingRangeProvider{Value: true},
-			HoverProvider:             &protocol.Or_ServerCapabilities_hoverProvider{Value: true},
-			DocumentHighlightProvider: &protocol.Or_ServerCapabilities_documentHighlightProvider{Value: true},
-			DocumentLinkProvider:      &protocol.DocumentLinkOptions{},
-			InlayHintProvider:         protocol.InlayHintOptions{},
-			DiagnosticProvider:        diagnosticProvider,
-			ReferencesProvider:        &protocol.Or_ServerCapabilities_referencesProvider{Value: true},
-			RenameProvider:            renameOpts,
-			SelectionRangeProvider:    &protocol.Or_ServerCapabilities_selectionRangeProvider{Value: true},
-			SemanticTokensProvider:    semanticTokenProvider,
+			FoldingRangeProvider:       &protocol.Or_ServerCapabilities_foldingRangeProvider{Value: true},
+			HoverProvider:              &protocol.Or_ServerCapabilities_hoverProvider{Value: true},
+			DocumentHighlightProvider:  &protocol.Or_ServerCapabilities_documentHighlightProvider{Value: true},
+			DocumentLinkProvider:       &protocol.DocumentLinkOptions{},
+			InlayHintProvider:          protocol.InlayHintOptions{},
+			InlineValueProvider:        &protocol.Or_ServerCapabilities_inlineValueProvider{Value: true},
+			LinkedEditingRangeProvider: &protocol.Or_ServerCapabilities_linkedEditingRangeProvider{Value: true},
+			MonikerProvider:            &protocol.Or_ServerCapabilities_monikerProvider{Value: true},
+			DiagnosticProvider:         diagnosticProvider,
+			ReferencesProvider:         &protocol.Or_ServerCapabilities_referencesProvider{Value: true},
+			RenameProvider:             renameOpts,
+			SelectionRangeProvider:     &protocol.Or_ServerCapabilities_selectionRangeProvider{Value: true},
+			SemanticTokensProvider:     semanticTokenProvider,
 			SignatureHelpProvider: &protocol.SignatureHelpOptions{
 				TriggerCharacters: []string{"(", ","},
 				// Used to update or dismiss signature help when it's already active,
@@ -250,6 +263,29 @@ func (s *server) Initialize(ctx context.Context, params *protocol.ParamInitializ
 							Pattern: protocol.FileOperationPattern{Glob: "**/*.go"},
 						}},
 					},
+					WillRename: &protocol.FileOperationRegistrationOptions{
+						Filters: []protocol.FileOperationFilter{{
+							Scheme: "file",
+							// Moving a directory may change the import
+							// paths of the packages it contains.
+							Pattern: protocol.FileOperationPattern{
+								Glob:    "**",
+								Matches: &folderPattern,
+							},
+						}},
+					},
+					WillDelete: &protocol.FileOperationRegistrationOptions{
+						Filters: []protocol.FileOperationFilter{{
+							Scheme:  "file",
+							Pattern: protocol.FileOperationPattern{Glob: "**/*.go"},
+						}},
+					},
+					DidDelete: &protocol.FileOperationRegistrationOptions{
+						Filters: []protocol.FileOperationFilter{{
+							Scheme:  "file",
+							Pattern: protocol.FileOperationPattern{Glob: "**/*.go"},
+						}},
+					},
 				},
 			},
 			Experimental: map[string]any{
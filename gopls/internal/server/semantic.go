@@ -6,6 +6,8 @@ package server
 
 import (
 	"context"
+	"maps"
+	"strconv"
 
 	"golang.org/x/tools/gopls/internal/file"
 	"golang.org/x/tools/gopls/internal/golang"
@@ -16,7 +18,97 @@ import (
 )
 
 func (s *server) SemanticTokensFull(ctx context.Context, params *protocol.SemanticTokensParams) (*protocol.SemanticTokens, error) {
-	return s.semanticTokens(ctx, params.TextDocument, nil)
+	// The client has no use for previous results.
+	s.forgetSemanticTokens(params.TextDocument.URI)
+	return s.semanticTokensFull(ctx, params.TextDocument)
+}
+
+// SemanticTokensFullDelta returns the edits that transform the tokens
+// of the previous result for the document with the specified result
+// ID into the current tokens; if that result is unknown, for example
+// because it is stale, it returns the current tokens in full.
+func (s *server) SemanticTokensFullDelta(ctx context.Context, params *protocol.SemanticTokensDeltaParams) (any, error) {
+	key := semanticTokensKey{params.TextDocument.URI, params.PreviousResultID}
+	s.lastSemanticTokensMu.Lock()
+	prev, ok := s.lastSemanticTokens[key]
+	delete(s.lastSemanticTokens, key) // the client replaces it by the new result
+	s.lastSemanticTokensMu.Unlock()
+
+	tokens, err := s.semanticTokensFull(ctx, params.TextDocument)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return tokens, nil
+	}
+	return &protocol.SemanticTokensDelta{
+		ResultID: tokens.ResultID,
+		Edits:    semanticTokensEdits(prev, tokens.Data),
+	}, nil
+}
+
+// A semanticTokensKey identifies a semantic tokens result.
+type semanticTokensKey struct {
+	uri      protocol.DocumentURI
+	resultID string
+}
+
+// semanticTokensFull computes the semantic tokens of the entire
+// document, assigns them a new result ID, and records them as a
+// basis of later delta requests for the document.
+func (s *server) semanticTokensFull(ctx context.Context, td protocol.TextDocumentIdentifier) (*protocol.SemanticTokens, error) {
+	tokens, err := s.semanticTokens(ctx, td, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s.lastSemanticTokensMu.Lock()
+	defer s.lastSemanticTokensMu.Unlock()
+	s.lastSemanticTokensID++
+	tokens.ResultID = strconv.FormatUint(s.lastSemanticTokensID, 10)
+	s.lastSemanticTokens[semanticTokensKey{td.URI, tokens.ResultID}] = tokens.Data
+	return tokens, nil
+}
+
+// forgetSemanticTokens discards the recorded semantic tokens results
+// for the document.
+func (s *server) forgetSemanticTokens(uri protocol.DocumentURI) {
+	s.lastSemanticTokensMu.Lock()
+	defer s.lastSemanticTokensMu.Unlock()
+	maps.DeleteFunc(s.lastSemanticTokens, func(key semanticTokensKey, _ []uint32) bool {
+		return key.uri == uri
+	})
+}
+
+// semanticTokensEdits returns the edits that transform the encoded
+// tokens before into after. There is at most one edit, replacing the
+// span between the longest common prefix and suffix of whole tokens.
+func semanticTokensEdits(before, after []uint32) []protocol.SemanticTokensEdit {
+	// Each token is encoded as 5 integers;
+	// see [protocol.SemanticTokens.Data].
+	const tokenLen = 5
+
+	prefix := 0
+	for prefix < len(before) && prefix < len(after) && before[prefix] == after[prefix] {
+		prefix++
+	}
+	prefix -= prefix % tokenLen
+
+	suffix := 0
+	for suffix < len(before)-prefix && suffix < len(after)-prefix &&
+		before[len(before)-1-suffix] == after[len(after)-1-suffix] {
+		suffix++
+	}
+	suffix -= suffix % tokenLen
+
+	if prefix == len(before) && prefix == len(after) {
+		return []protocol.SemanticTokensEdit{} // unchanged
+	}
+	return []protocol.SemanticTokensEdit{{
+		Start:       uint32(prefix),
+		DeleteCount: uint32(len(before) - prefix - suffix),
+		Data:        after[prefix : len(after)-suffix],
+	}}
 }
 
 func (s *server) SemanticTokensRange(ctx context.Context, params *protocol.SemanticTokensRangeParams) (*protocol.SemanticTokens, error) {
@@ -48,6 +48,7 @@ func New(session *cache.Session, client protocol.ClientCloser, options *settings
 		diagnostics:         make(map[protocol.DocumentURI]*fileDiagnostics),
 		watchedGlobPatterns: nil, // empty
 		changedFiles:        make(map[protocol.DocumentURI]unit),
+		lastSemanticTokens:  make(map[semanticTokensKey][]uint32),
 		session:             session,
 		client:              client,
 		diagnosticsSema:     make(chan unit, concurrentAnalyses),
@@ -118,6 +119,13 @@ type server struct {
 	diagnosticsMu sync.Mutex // guards map and its values
 	diagnostics   map[protocol.DocumentURI]*fileDiagnostics
 
+	// lastSemanticTokens holds the data of recent semantic tokens
+	// results, keyed by document and result ID, against which
+	// textDocument/semanticTokens/full/delta requests are computed.
+	lastSemanticTokensMu sync.Mutex
+	lastSemanticTokens   map[semanticTokensKey][]uint32
+	lastSemanticTokensID uint64 // incrementing clock for result IDs
+
 	// diagnosticsSema limits the concurrency of diagnostics runs, which can be
 	// expensive.
 	diagnosticsSema chan unit
@@ -63,6 +63,9 @@ const (
 	// FromToggleCompilerOptDetails refers to state changes resulting from toggling
 	// a package's compiler optimization details flag.
 	FromToggleCompilerOptDetails
+
+	// FromDidDeleteFiles is from a workspace/didDeleteFiles notification.
+	FromDidDeleteFiles
 )
 
 func (m ModificationSource) String() string {
@@ -85,6 +88,8 @@ func (m ModificationSource) String() string {
 		return "from check upgrades"
 	case FromResetGoModDiagnostics:
 		return "from resetting go.mod diagnostics"
+	case FromDidDeleteFiles:
+		return "from didDeleteFiles"
 	default:
 		return "unknown file modification"
 	}
@@ -204,6 +209,8 @@ func (s *server) DidClose(ctx context.Context, params *protocol.DidCloseTextDocu
 	ctx, done := event.Start(ctx, "server.DidClose", label.URI.Of(params.TextDocument.URI))
 	defer done()
 
+	s.forgetSemanticTokens(params.TextDocument.URI)
+
 	return s.didModifyFiles(ctx, FromDidClose, file.Modification{
 		URI:     params.TextDocument.URI,
 		Action:  file.Close,
@@ -13,6 +13,7 @@ import (
 
 	"golang.org/x/tools/gopls/internal/cache"
 	"golang.org/x/tools/gopls/internal/file"
+	"golang.org/x/tools/gopls/internal/golang"
 	"golang.org/x/tools/gopls/internal/golang/completion"
 	"golang.org/x/tools/gopls/internal/protocol"
 	"golang.org/x/tools/gopls/internal/settings"
@@ -176,3 +177,99 @@ func (s *server) DidCreateFiles(ctx context.Context, params *protocol.CreateFile
 
 	return applyChanges(ctx, s.client, allChanges)
 }
+
+// WillRenameFiles implements the workspace/willRenameFiles request,
+// which the client sends before it moves or renames files or
+// directories. The result updates import paths (and package clauses,
+// where appropriate) so that importers of a moved package still build.
+func (s *server) WillRenameFiles(ctx context.Context, params *protocol.RenameFilesParams) (*protocol.WorkspaceEdit, error) {
+	ctx, done := event.Start(ctx, "server.WillRenameFiles")
+	defer done()
+
+	if len(params.Files) == 0 {
+		return nil, nil
+	}
+
+	// All renamings are computed in the same snapshot so that
+	// edits to a common importer are combined.
+	snapshot, release, err := s.session.SnapshotOf(ctx, params.Files[0].OldURI)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	changes, err := golang.RenameFiles(ctx, snapshot, params.Files)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	return protocol.NewWorkspaceEdit(changes...), nil
+}
+
+// WillDeleteFiles implements the workspace/willDeleteFiles request,
+// which the client sends before it deletes files. It requires no
+// edits, but warns the user of any references from other packages to
+// declarations in the files, which would be left dangling.
+func (s *server) WillDeleteFiles(ctx context.Context, params *protocol.DeleteFilesParams) (*protocol.WorkspaceEdit, error) {
+	ctx, done := event.Start(ctx, "server.WillDeleteFiles")
+	defer done()
+
+	if len(params.Files) == 0 {
+		return nil, nil
+	}
+	uris := make([]protocol.DocumentURI, len(params.Files))
+	for i, f := range params.Files {
+		uris[i] = f.URI
+	}
+	snapshot, release, err := s.session.SnapshotOf(ctx, uris[0])
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	refs, err := golang.DeletedFileReferences(ctx, snapshot, uris)
+	if err != nil {
+		return nil, err
+	}
+	if len(refs) > 0 {
+		showMessage(ctx, s.client, protocol.Warning, danglingReferencesMessage(refs))
+	}
+	return nil, nil
+}
+
+// danglingReferencesMessage formats a warning about the references
+// that deleting files would leave dangling.
+func danglingReferencesMessage(refs []golang.DanglingReference) string {
+	const maxListed = 10 // maximum number of references to list
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "Deleting these files will break %d reference(s) from other packages:", len(refs))
+	for i, ref := range refs {
+		if i == maxListed {
+			fmt.Fprintf(&msg, "\n\t(and %d more)", len(refs)-maxListed)
+			break
+		}
+		fmt.Fprintf(&msg, "\n\t%s:%d:%d: %s", ref.Location.URI.Path(), ref.Location.Range.Start.Line+1, ref.Location.Range.Start.Character+1, ref.Name)
+	}
+	return msg.String()
+}
+
+// DidDeleteFiles implements the workspace/didDeleteFiles notification,
+// which the client sends after it deletes files. The files are removed
+// from the workspace immediately, rather than when the file watcher
+// reports their deletion.
+func (s *server) DidDeleteFiles(ctx context.Context, params *protocol.DeleteFilesParams) error {
+	ctx, done := event.Start(ctx, "server.DidDeleteFiles")
+	defer done()
+
+	modifications := make([]file.Modification, len(params.Files))
+	for i, f := range params.Files {
+		modifications[i] = file.Modification{
+			URI:    f.URI,
+			Action: file.Delete,
+			OnDisk: true,
+		}
+	}
+	return s.didModifyFiles(ctx, FromDidDeleteFiles, modifications...)
+}
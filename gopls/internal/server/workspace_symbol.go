@@ -6,6 +6,7 @@ package server
 
 import (
 	"context"
+	"slices"
 
 	"golang.org/x/tools/gopls/internal/cache"
 	"golang.org/x/tools/gopls/internal/golang"
@@ -14,7 +15,7 @@ import (
 	"golang.org/x/tools/internal/event"
 )
 
-func (s *server) Symbol(ctx context.Context, params *protocol.WorkspaceSymbolParams) (_ []protocol.SymbolInformation, rerr error) {
+func (s *server) Symbol(ctx context.Context, params *protocol.WorkspaceSymbolParams) (_ []protocol.WorkspaceSymbol, rerr error) {
 	recordLatency := telemetry.StartLatencyTimer("symbol")
 	defer func() {
 		recordLatency(ctx, rerr)
@@ -24,8 +25,12 @@ func (s *server) Symbol(ctx context.Context, params *protocol.WorkspaceSymbolPar
 	defer done()
 
 	views := s.session.Views()
-	matcher := s.Options().SymbolMatcher
-	style := s.Options().SymbolStyle
+	opts := golang.WorkspaceSymbolsOptions{
+		Matcher: s.Options().SymbolMatcher,
+		Style:   s.Options().SymbolStyle,
+		// Defer the computation of ranges to workspaceSymbol/resolve.
+		URIOnly: slices.Contains(s.Options().WorkspaceSymbolResolveOptions, "location.range"),
+	}
 
 	var snapshots []*cache.Snapshot
 	for _, v := range views {
@@ -37,5 +42,26 @@ func (s *server) Symbol(ctx context.Context, params *protocol.WorkspaceSymbolPar
 		defer release()
 		snapshots = append(snapshots, snapshot)
 	}
-	return golang.WorkspaceSymbols(ctx, snapshots, params.Query, golang.WorkspaceSymbolsOptions{Matcher: matcher, Style: style})
+	return golang.WorkspaceSymbols(ctx, snapshots, params.Query, opts)
+}
+
+func (s *server) ResolveWorkspaceSymbol(ctx context.Context, sym *protocol.WorkspaceSymbol) (*protocol.WorkspaceSymbol, error) {
+	ctx, done := event.Start(ctx, "server.ResolveWorkspaceSymbol")
+	defer done()
+
+	var uri protocol.DocumentURI
+	switch loc := sym.Location.Value.(type) {
+	case protocol.Location:
+		uri = loc.URI
+	case protocol.LocationUriOnly:
+		uri = loc.URI
+	default:
+		return sym, nil
+	}
+	snapshot, release, err := s.session.SnapshotOf(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return golang.ResolveWorkspaceSymbol(ctx, snapshot, sym)
 }
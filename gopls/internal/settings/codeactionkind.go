@@ -88,15 +88,21 @@ const (
 	GoplsDocFeatures protocol.CodeActionKind = "gopls.doc.features"
 
 	// refactor.rewrite
+	RefactorRewriteAddParam           protocol.CodeActionKind = "refactor.rewrite.addParam"
 	RefactorRewriteChangeQuote        protocol.CodeActionKind = "refactor.rewrite.changeQuote"
 	RefactorRewriteFillStruct         protocol.CodeActionKind = "refactor.rewrite.fillStruct"
 	RefactorRewriteFillSwitch         protocol.CodeActionKind = "refactor.rewrite.fillSwitch"
+	RefactorRewriteFuncToMethod       protocol.CodeActionKind = "refactor.rewrite.funcToMethod"
+	RefactorRewriteIfToSwitch         protocol.CodeActionKind = "refactor.rewrite.ifToSwitch"
 	RefactorRewriteInvertIf           protocol.CodeActionKind = "refactor.rewrite.invertIf"
 	RefactorRewriteJoinLines          protocol.CodeActionKind = "refactor.rewrite.joinLines"
+	RefactorRewriteMethodToFunc       protocol.CodeActionKind = "refactor.rewrite.methodToFunc"
 	RefactorRewriteRemoveUnusedParam  protocol.CodeActionKind = "refactor.rewrite.removeUnusedParam"
 	RefactorRewriteMoveParamLeft      protocol.CodeActionKind = "refactor.rewrite.moveParamLeft"
 	RefactorRewriteMoveParamRight     protocol.CodeActionKind = "refactor.rewrite.moveParamRight"
+	RefactorRewriteParamStruct        protocol.CodeActionKind = "refactor.rewrite.paramStruct"
 	RefactorRewriteSplitLines         protocol.CodeActionKind = "refactor.rewrite.splitLines"
+	RefactorRewriteSwitchToIf         protocol.CodeActionKind = "refactor.rewrite.switchToIf"
 	RefactorRewriteEliminateDotImport protocol.CodeActionKind = "refactor.rewrite.eliminateDotImport"
 	RefactorRewriteAddTags            protocol.CodeActionKind = "refactor.rewrite.addTags"
 	RefactorRewriteImplementInterface protocol.CodeActionKind = "refactor.rewrite.implementInterface"
@@ -110,6 +116,7 @@ const (
 	RefactorExtractConstant    protocol.CodeActionKind = "refactor.extract.constant"
 	RefactorExtractConstantAll protocol.CodeActionKind = "refactor.extract.constant-all"
 	RefactorExtractFunction    protocol.CodeActionKind = "refactor.extract.function"
+	RefactorExtractInterface   protocol.CodeActionKind = "refactor.extract.interface"
 	RefactorExtractMethod      protocol.CodeActionKind = "refactor.extract.method"
 	RefactorExtractVariable    protocol.CodeActionKind = "refactor.extract.variable"
 	RefactorExtractVariableAll protocol.CodeActionKind = "refactor.extract.variable-all"
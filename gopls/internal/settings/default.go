@@ -55,19 +55,26 @@ func DefaultOptions(overrides ...func(*Options)) *Options {
 						GoFreeSymbols:                     true,
 						GoSplitPackage:                    true,
 						GoplsDocFeatures:                  true,
+						RefactorRewriteAddParam:           true, // gated by client support for interactive forms
 						RefactorRewriteChangeQuote:        true,
 						RefactorRewriteFillStruct:         true,
 						RefactorRewriteFillSwitch:         true,
+						RefactorRewriteFuncToMethod:       true,
+						RefactorRewriteIfToSwitch:         true,
 						RefactorRewriteImplementInterface: true,
 						RefactorRewriteInvertIf:           true,
 						RefactorRewriteJoinLines:          true,
+						RefactorRewriteMethodToFunc:       true,
+						RefactorRewriteParamStruct:        true,
 						RefactorRewriteRemoveUnusedParam:  true,
 						RefactorRewriteSplitLines:         true,
+						RefactorRewriteSwitchToIf:         true,
 						RefactorInlineCall:                true,
 						RefactorInlineVariable:            true,
 						RefactorExtractConstant:           true,
 						RefactorExtractConstantAll:        true,
 						RefactorExtractFunction:           true,
+						RefactorExtractInterface:          true, // gated by client support for interactive forms
 						RefactorExtractMethod:             true,
 						RefactorExtractVariable:           true,
 						RefactorExtractVariableAll:        true,
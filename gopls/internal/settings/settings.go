@@ -106,6 +106,8 @@ type ClientOptions struct {
 	CompletionDeprecated                       bool
 	SupportedResourceOperations                []protocol.ResourceOperationKind
 	CodeActionResolveOptions                   []string
+	CompletionResolveOptions                   []string
+	WorkspaceSymbolResolveOptions              []string
 	ShowDocumentSupported                      bool
 	// SupportedWorkDoneProgressFormats specifies the formats supported by the
 	// client for handling workdone progress metadata.
@@ -1122,6 +1124,16 @@ func (o *Options) ForClientCapabilities(clientInfo *protocol.ClientInfo, caps pr
 		o.CompletionDeprecated = true
 	}
 
+	// Check which completion item properties the client can resolve lazily.
+	if rs := caps.TextDocument.Completion.CompletionItem.ResolveSupport; rs != nil {
+		o.CompletionResolveOptions = rs.Properties
+	}
+
+	// Check which workspace symbol properties the client can resolve lazily.
+	if caps.Workspace.Symbol != nil && caps.Workspace.Symbol.ResolveSupport != nil {
+		o.WorkspaceSymbolResolveOptions = caps.Workspace.Symbol.ResolveSupport.Properties
+	}
+
 	// Check if the client supports code actions resolving.
 	if caps.TextDocument.CodeAction.DataSupport && caps.TextDocument.CodeAction.ResolveSupport != nil {
 		o.CodeActionResolveOptions = caps.TextDocument.CodeAction.ResolveSupport.Properties
@@ -1681,3 +1681,93 @@ func Bar() {
 		}
 	})
 }
+
+func TestResolveCompletionItem(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.21
+-- main.go --
+package main
+
+// Frobnicate frobnicates.
+//
+// It is very expensive.
+func Frobnicate() {}
+
+func main() {
+	Frob
+	strings.ToUpp
+}
+`
+	// The client resolves documentation and import edits lazily.
+	capabilities := `{"textDocument": {"completion": {"completionItem": {"resolveSupport": {"properties": ["documentation", "additionalTextEdits"]}}}}}`
+	WithOptions(
+		CapabilitiesJSON([]byte(capabilities)),
+		Settings{"completeUnimported": true},
+	).Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("main.go")
+		env.Await(env.DoneWithOpen())
+
+		// complete finds the completion item with the given label at
+		// the end of the given pattern, and checks that its deferred
+		// properties are absent.
+		complete := func(re, label string) protocol.CompletionItem {
+			t.Helper()
+			completions := env.Completion(env.RegexpSearch("main.go", re))
+			i := slices.IndexFunc(completions.Items, func(item protocol.CompletionItem) bool {
+				return item.Label == label
+			})
+			if i < 0 {
+				t.Fatalf("no completion item %q at %s", label, re)
+			}
+			item := completions.Items[i]
+			if item.Documentation != nil || len(item.AdditionalTextEdits) > 0 {
+				t.Errorf("completion item %q was eagerly resolved: %+v", label, item)
+			}
+			if item.Data == nil {
+				t.Fatalf("completion item %q has no data to resolve", label)
+			}
+			return item
+		}
+		resolveItem := func(item protocol.CompletionItem) *protocol.CompletionItem {
+			t.Helper()
+			resolved, err := env.Editor.Server.ResolveCompletionItem(env.Ctx, &item)
+			if err != nil {
+				t.Fatalf("ResolveCompletionItem(%q) failed: %v", item.Label, err)
+			}
+			return resolved
+		}
+		resolve := func(re, label string) *protocol.CompletionItem {
+			t.Helper()
+			return resolveItem(complete(re, label))
+		}
+
+		item := resolve(`\tFrob()`, "Frobnicate")
+		if item.Documentation == nil || !strings.Contains(fmt.Sprint(item.Documentation.Value), "Frobnicate frobnicates.") {
+			t.Errorf("resolved Frobnicate has documentation %v, want synopsis", item.Documentation)
+		}
+
+		// Edits to the declaring file that move the declaration don't
+		// affect the documentation of items that are not yet resolved.
+		unresolved := complete(`\tFrob()`, "Frobnicate")
+		env.EditBuffer("main.go", protocol.TextEdit{
+			Range:   protocol.Range{Start: protocol.Position{Line: 2}, End: protocol.Position{Line: 2}},
+			NewText: "// Other is another function.\nfunc Other() {}\n\n",
+		})
+		item = resolveItem(unresolved)
+		if item.Documentation == nil || !strings.Contains(fmt.Sprint(item.Documentation.Value), "Frobnicate frobnicates.") {
+			t.Errorf("resolved Frobnicate after an edit has documentation %v, want synopsis", item.Documentation)
+		}
+
+		item = resolve(`ToUpp()`, "ToUpper")
+		if len(item.AdditionalTextEdits) == 0 {
+			t.Fatalf("resolved ToUpper has no import edits")
+		}
+		env.EditBuffer("main.go", item.AdditionalTextEdits...)
+		if got := env.BufferText("main.go"); !strings.Contains(got, `import "strings"`) {
+			t.Errorf("resolved ToUpper import edits produced:\n%s\nwant an import of strings", got)
+		}
+	})
+}
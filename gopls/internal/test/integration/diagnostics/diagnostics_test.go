@@ -127,6 +127,88 @@ package consts
 const A = 2
 `
 
+func TestWorkspaceDiagnostics(t *testing.T) {
+	// workspaceReport summarizes one file report of a
+	// workspace/diagnostic response.
+	type workspaceReport struct {
+		kind     string
+		resultID string
+		items    int
+	}
+	workspaceDiagnostics := func(env *Env, previous map[string]string) map[string]workspaceReport {
+		t.Helper()
+		params := &protocol.WorkspaceDiagnosticParams{}
+		for name, id := range previous {
+			params.PreviousResultIds = append(params.PreviousResultIds, protocol.PreviousResultID{
+				URI:   env.Sandbox.Workdir.URI(name),
+				Value: id,
+			})
+		}
+		report, err := env.Editor.Server.DiagnosticWorkspace(env.Ctx, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := make(map[string]workspaceReport)
+		for _, item := range report.Items {
+			switch item := item.Value.(type) {
+			case protocol.WorkspaceFullDocumentDiagnosticReport:
+				got[env.Sandbox.Workdir.URIToPath(item.URI)] = workspaceReport{item.Kind, item.ResultID, len(item.Items)}
+			case protocol.WorkspaceUnchangedDocumentDiagnosticReport:
+				got[env.Sandbox.Workdir.URIToPath(item.URI)] = workspaceReport{item.Kind, item.ResultID, 0}
+			default:
+				t.Fatalf("unexpected report type %T", item)
+			}
+		}
+		return got
+	}
+
+	WithOptions(
+		Settings{
+			"pullDiagnostics": true,
+		},
+	).Run(t, badPackage, func(t *testing.T, env *Env) {
+		// The initial request reports full diagnostics for all files.
+		first := workspaceDiagnostics(env, nil)
+		previous := make(map[string]string)
+		for _, f := range []string{"a.go", "b.go"} {
+			r := first[f]
+			if r.kind != "full" || r.items != 1 || r.resultID == "" {
+				t.Errorf("workspace/diagnostic(%s) = %+v, want a full report with 1 item", f, r)
+			}
+			previous[f] = r.resultID
+		}
+
+		// Without any change, all files are unchanged.
+		second := workspaceDiagnostics(env, previous)
+		for _, f := range []string{"a.go", "b.go"} {
+			if r := second[f]; r.kind != "unchanged" || r.resultID != previous[f] {
+				t.Errorf("workspace/diagnostic(%s) = %+v, want unchanged report with result ID %s", f, r, previous[f])
+			}
+		}
+
+		// Fix the error by editing the const name A in b.go to `B`.
+		env.OpenFile("b.go")
+		env.RegexpReplace("b.go", "(A) = 2", "B")
+		third := workspaceDiagnostics(env, previous)
+		for _, f := range []string{"a.go", "b.go"} {
+			if r := third[f]; r.kind != "full" || r.items != 0 || r.resultID == previous[f] {
+				t.Errorf("workspace/diagnostic(%s) = %+v, want a full report with 0 items", f, r)
+			}
+			previous[f] = third[f].resultID
+		}
+
+		// An edit that doesn't affect diagnostics yields unchanged
+		// reports, with new result IDs.
+		env.RegexpReplace("b.go", "B = 2", "B = 3")
+		fourth := workspaceDiagnostics(env, previous)
+		for _, f := range []string{"a.go", "b.go"} {
+			if r := fourth[f]; r.kind != "unchanged" || r.resultID == previous[f] {
+				t.Errorf("workspace/diagnostic(%s) = %+v, want unchanged report with a new result ID", f, r)
+			}
+		}
+	})
+}
+
 func TestDiagnosticClearingOnEdit(t *testing.T) {
 	WithOptions(
 		Settings{
@@ -321,6 +321,7 @@ func (e *Env) DoneDiagnosingChanges() Expectation {
 		server.FromDidChangeWatchedFiles:  stats.DidChangeWatchedFiles,
 		server.FromDidClose:               stats.DidClose,
 		server.FromDidChangeConfiguration: stats.DidChangeConfiguration,
+		server.FromDidDeleteFiles:         stats.DidDeleteFiles,
 	}
 
 	var expected []server.ModificationSource
@@ -351,6 +352,7 @@ func (e *Env) DoneDiagnosingChanges() Expectation {
 //   - textDocument/didClose
 //   - workspace/didChangeWatchedFiles
 //   - workspace/didChangeConfiguration
+//   - workspace/didDeleteFiles
 func (e *Env) AfterChange(expectations ...Expectation) {
 	e.TB.Helper()
 	e.OnceMet(
@@ -63,7 +63,7 @@ type Editor struct {
 
 // CallCounts tracks the number of protocol notifications of different types.
 type CallCounts struct {
-	DidOpen, DidChange, DidSave, DidChangeWatchedFiles, DidClose, DidChangeConfiguration uint64
+	DidOpen, DidChange, DidSave, DidChangeWatchedFiles, DidClose, DidChangeConfiguration, DidDeleteFiles uint64
 }
 
 // buffer holds information about an open buffer in the editor.
@@ -958,8 +958,7 @@ func (e *Editor) TypeDefinitions(ctx context.Context, loc protocol.Location) ([]
 
 // Symbol performs a workspace symbol search using query
 func (e *Editor) Symbol(ctx context.Context, query string) ([]protocol.SymbolInformation, error) {
-	params := &protocol.WorkspaceSymbolParams{Query: query}
-	return e.Server.Symbol(ctx, params)
+	return e.Symbols(ctx, query)
 }
 
 // OrganizeImports requests and performs the source.organizeImports codeAction.
@@ -1337,14 +1336,37 @@ func (e *Editor) AcceptCompletion(ctx context.Context, loc protocol.Location, it
 	}, item.AdditionalTextEdits...))
 }
 
-// Symbols executes a workspace/symbols request on the server.
+// Symbols executes a workspace/symbols request on the server, and
+// resolves the ranges of symbols whose locations specify only a file.
 func (e *Editor) Symbols(ctx context.Context, sym string) ([]protocol.SymbolInformation, error) {
 	if e.Server == nil {
 		return nil, nil
 	}
 	params := &protocol.WorkspaceSymbolParams{Query: sym}
-	ans, err := e.Server.Symbol(ctx, params)
-	return ans, err
+	syms, err := e.Server.Symbol(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	var ans []protocol.SymbolInformation
+	for _, sym := range syms {
+		// A URI-only location is decoded as a Location with an empty range.
+		loc, ok := sym.Location.Value.(protocol.Location)
+		if !ok || loc.Range == (protocol.Range{}) {
+			resolved, err := e.Server.ResolveWorkspaceSymbol(ctx, &sym)
+			if err != nil {
+				return nil, err
+			}
+			loc, _ = resolved.Location.Value.(protocol.Location)
+		}
+		ans = append(ans, protocol.SymbolInformation{
+			Location:      loc,
+			Name:          sym.Name,
+			Kind:          sym.Kind,
+			Tags:          sym.Tags,
+			ContainerName: sym.ContainerName,
+		})
+	}
+	return ans, nil
 }
 
 // InlayHint executes an inlay hint request on the server.
@@ -1481,6 +1503,70 @@ func (e *Editor) RenameFile(ctx context.Context, oldPath, newPath string) error
 	return nil
 }
 
+// WillRenameFiles sends a workspace/willRenameFiles request for the
+// renaming of oldPath to newPath, and applies the resulting workspace
+// edit, if any. It does not perform the renaming itself: see RenameFile.
+func (e *Editor) WillRenameFiles(ctx context.Context, oldPath, newPath string) error {
+	if e.Server == nil {
+		return nil
+	}
+	params := &protocol.RenameFilesParams{
+		Files: []protocol.FileRename{{
+			OldURI: e.sandbox.Workdir.URI(oldPath),
+			NewURI: e.sandbox.Workdir.URI(newPath),
+		}},
+	}
+	wsedit, err := e.Server.WillRenameFiles(ctx, params)
+	if err != nil {
+		return err
+	}
+	if wsedit == nil {
+		return nil
+	}
+	return e.applyWorkspaceEdit(ctx, wsedit)
+}
+
+// DeleteFiles deletes the given workdir-relative files as an editor
+// would: it sends a workspace/willDeleteFiles request and applies the
+// resulting workspace edit, if any, closes any open buffers for the
+// files, removes them from disk, and sends a workspace/didDeleteFiles
+// notification. The file watcher is not notified of the deletion.
+func (e *Editor) DeleteFiles(ctx context.Context, paths ...string) error {
+	if e.Server == nil {
+		return nil
+	}
+	params := &protocol.DeleteFilesParams{}
+	for _, path := range paths {
+		params.Files = append(params.Files, protocol.FileDelete{URI: e.sandbox.Workdir.URI(path)})
+	}
+	wsedit, err := e.Server.WillDeleteFiles(ctx, params)
+	if err != nil {
+		return err
+	}
+	if wsedit != nil {
+		if err := e.applyWorkspaceEdit(ctx, wsedit); err != nil {
+			return err
+		}
+	}
+	for _, path := range paths {
+		if e.HasBuffer(path) {
+			if err := e.CloseBuffer(ctx, path); err != nil {
+				return err
+			}
+		}
+		if err := os.Remove(e.sandbox.Workdir.AbsPath(path)); err != nil {
+			return fmt.Errorf("deleting sandbox file: %w", err)
+		}
+	}
+	if err := e.Server.DidDeleteFiles(ctx, params); err != nil {
+		return fmt.Errorf("DidDeleteFiles: %w", err)
+	}
+	e.callsMu.Lock()
+	e.calls.DidDeleteFiles++
+	e.callsMu.Unlock()
+	return nil
+}
+
 // renameBuffers renames in-memory buffers affected by the renaming of
 // oldPath->newPath, returning the resulting text documents that must be closed
 // and opened over the LSP.
@@ -1779,6 +1865,21 @@ func (e *Editor) DocumentHighlight(ctx context.Context, loc protocol.Location) (
 	return e.Server.DocumentHighlight(ctx, params)
 }
 
+// LinkedEditingRange invokes textDocument/linkedEditingRange at the given
+// location.
+func (e *Editor) LinkedEditingRange(ctx context.Context, loc protocol.Location) (*protocol.LinkedEditingRanges, error) {
+	if e.Server == nil {
+		return nil, nil
+	}
+	if err := e.checkBufferLocation(loc); err != nil {
+		return nil, err
+	}
+	params := &protocol.LinkedEditingRangeParams{
+		TextDocumentPositionParams: protocol.LocationTextDocumentPositionParams(loc),
+	}
+	return e.Server.LinkedEditingRange(ctx, params)
+}
+
 // SemanticTokensFull invokes textDocument/semanticTokens/full, and interprets
 // its result.
 func (e *Editor) SemanticTokensFull(ctx context.Context, path string) ([]SemanticToken, error) {
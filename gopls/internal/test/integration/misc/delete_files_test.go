@@ -0,0 +1,59 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"testing"
+
+	. "golang.org/x/tools/gopls/internal/test/integration"
+)
+
+func TestDeleteFiles(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.21
+-- a/a.go --
+package a
+
+func G() {}
+-- a/a2.go --
+package a
+
+type T struct{ F int }
+
+func (T) M() {}
+
+func unexported() {}
+-- b/b.go --
+package b
+
+import "mod.com/a"
+
+func _(t a.T) {
+	a.G()
+	t.M()
+	_ = t.F
+}
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("b/b.go")
+		env.AfterChange(NoDiagnostics(ForFile("b/b.go")))
+
+		env.DeleteFiles("a/a2.go")
+		env.AfterChange(
+			// willDeleteFiles warns of the references to the deleted declarations...
+			ShownMessage("Deleting these files will break 3 reference(s) from other packages"),
+			ShownMessage("b.go:5:12: a.T"),
+			ShownMessage("b.go:7:4: a.T.M"),
+			ShownMessage("b.go:8:8: a.T.F"),
+			NoShownMessage("a.G"),
+			// ...and didDeleteFiles updates the workspace without
+			// waiting for the file watcher.
+			Diagnostics(env.AtRegexp("b/b.go", `a\.(T)`), WithMessage("undefined: a.T")),
+		)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"golang.org/x/tools/gopls/internal/protocol"
 	"golang.org/x/tools/gopls/internal/test/compare"
 	. "golang.org/x/tools/gopls/internal/test/integration"
 )
@@ -355,3 +356,128 @@ func _() {
 		env.FormatBuffer("foo.go") // golang/go#61692: must not panic
 	})
 }
+
+func TestOnTypeFormatting(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.21
+-- a.go --
+package a
+`
+	for _, test := range []struct {
+		name string
+		ch   string
+		src  string
+		re   string // the cursor is at the end of the first match
+		want string
+	}{
+		{
+			name: "closing brace",
+			ch:   "}",
+			src: `package a
+
+func f() {
+	x := []int{
+	1,
+	  2,
+		}
+	_ = x
+}
+
+func g() { y  :=  1; _ = y }
+`,
+			re: `\t\t}`,
+			want: `package a
+
+func f() {
+	x := []int{
+		1,
+		2,
+	}
+	_ = x
+}
+
+func g() { y  :=  1; _ = y }
+`,
+		},
+		{
+			name: "semicolon",
+			ch:   ";",
+			src: `package a
+
+func f() {
+	x  :=  1;
+	_  =  x
+}
+`,
+			re: `1;`,
+			want: `package a
+
+func f() {
+	x := 1;
+	_  =  x
+}
+`,
+		},
+		{
+			name: "newline",
+			ch:   "\n",
+			src: `package a
+
+func f() {
+	if  true  {
+		
+	}
+	x  :=  1
+}
+`,
+			re: `{\n\t\t`,
+			want: `package a
+
+func f() {
+	if true {
+		
+	}
+	x  :=  1
+}
+`,
+		},
+		{
+			name: "parse error",
+			ch:   "}",
+			src: `package a
+
+func f() {
+	x  :=  []int{}
+`,
+			re: `{}`,
+			want: `package a
+
+func f() {
+	x  :=  []int{}
+`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			Run(t, files, func(t *testing.T, env *Env) {
+				env.OpenFile("a.go")
+				env.SetBufferContent("a.go", test.src)
+				loc := env.RegexpSearch("a.go", test.re)
+				edits, err := env.Editor.Server.OnTypeFormatting(env.Ctx, &protocol.DocumentOnTypeFormattingParams{
+					TextDocument: protocol.TextDocumentIdentifier{URI: loc.URI},
+					Position:     loc.Range.End,
+					Ch:           test.ch,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				env.EditBuffer("a.go", edits...)
+				if got := env.BufferText("a.go"); got != test.want {
+					t.Errorf("unexpected formatting result:\n%s", compare.Text(test.want, got))
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/tools/gopls/internal/protocol"
+	. "golang.org/x/tools/gopls/internal/test/integration"
+)
+
+func TestInlineValue(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.22
+-- a.go --
+package a
+
+var global int
+
+type T struct{ f, g int }
+
+func (t *T) M(n int) int {
+	sum := t.f
+	for i := range n {
+		sum += i * t.g
+		if x := i; x > 0 {
+			sum := x
+			_ = sum
+		}
+		func(y int) {
+			sum += y
+		}(i)
+		sum += global // stop here
+	}
+	return sum
+}
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a.go")
+		stop := env.RegexpSearch("a.go", `sum \+= global`)
+		whole := protocol.Range{End: env.RegexpSearch("a.go", `return sum`).Range.End}
+		values, err := env.Editor.Server.InlineValue(env.Ctx, &protocol.InlineValueParams{
+			TextDocument: protocol.TextDocumentIdentifier{URI: stop.URI},
+			Range:        whole,
+			Context: protocol.InlineValueContext{
+				StoppedLocation: stop.Range,
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The client cannot distinguish the kinds of inline value
+		// (all are decoded as InlineValueEvaluatableExpression),
+		// so describe each by its range and expression, if any.
+		content := env.BufferText("a.go")
+		mapper := protocol.NewMapper(stop.URI, []byte(content))
+		var got []string
+		for _, v := range values {
+			v, ok := v.Value.(protocol.InlineValueEvaluatableExpression)
+			if !ok {
+				t.Fatalf("unexpected inline value %T", v)
+			}
+			start, end, err := mapper.RangeOffsets(v.Range)
+			if err != nil {
+				t.Fatal(err)
+			}
+			desc := fmt.Sprintf("%d:%d %s", v.Range.Start.Line, v.Range.Start.Character, content[start:end])
+			if v.Expression != "" {
+				desc += " = " + v.Expression
+			}
+			got = append(got, desc)
+		}
+		// Not reported: global (package-level), x and the inner sum
+		// (not in scope), y (not in the stopped function), and
+		// occurrences after the stopped line.
+		want := []string{
+			"6:6 t",
+			"6:14 n",
+			"7:1 sum",
+			"7:8 t.f = t.f",
+			"8:5 i",
+			"8:16 n",
+			"9:2 sum",
+			"9:9 i",
+			"9:13 t.g = t.g",
+			"10:10 i",
+			"15:3 sum",
+			"16:4 i",
+			"17:2 sum",
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("InlineValue mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
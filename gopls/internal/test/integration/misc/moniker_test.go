@@ -0,0 +1,85 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/tools/gopls/internal/protocol"
+	. "golang.org/x/tools/gopls/internal/test/integration"
+)
+
+func TestMoniker(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.21
+-- a/a.go --
+package a
+
+import "fmt"
+
+type T struct {
+	Field int
+	inner struct{ x int }
+}
+
+func (T) Method() {}
+
+func F(t T) {
+	local := t.Field
+	fmt.Println(local, len("x"))
+	t.Method()
+	_ = t.inner.x
+}
+-- b/b.go --
+package b
+
+import "mod.com/a"
+
+func _() {
+	a.F(a.T{})
+}
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.go")
+		env.OpenFile("b/b.go")
+		for _, test := range []struct {
+			file, re string
+			want     string // "scheme identifier unique kind"
+		}{
+			{"a/a.go", `package (a)`, "go mod.com mod.com/a global export"},
+			{"a/a.go", `type (T)`, "go mod.com mod.com/a T global export"},
+			{"a/a.go", `(Field) int`, "go mod.com mod.com/a T.Field global export"},
+			{"a/a.go", `func \(T\) (Method)`, "go mod.com mod.com/a T.Method global export"},
+			{"a/a.go", `t\.(Method)`, "go mod.com mod.com/a T.Method global export"},
+			{"a/a.go", `inner\.(x)`, "go mod.com mod.com/a T.UF1.F0 global local"},
+			{"a/a.go", `(local) :=`, "go local:115 document local"},
+			{"a/a.go", `fmt\.(Println)`, "go std fmt Println global import"},
+			{"a/a.go", `(fmt)\.Println`, "go std fmt global import"},
+			{"a/a.go", `(len)\(`, "go std builtin len global import"},
+			{"b/b.go", `a\.(F)`, "go mod.com mod.com/a F global export"},
+		} {
+			loc := env.RegexpSearch(test.file, test.re)
+			monikers, err := env.Editor.Server.Moniker(env.Ctx, &protocol.MonikerParams{
+				TextDocumentPositionParams: protocol.LocationTextDocumentPositionParams(loc),
+			})
+			if err != nil {
+				t.Fatalf("Moniker(%s, %q) failed: %v", test.file, test.re, err)
+			}
+			if len(monikers) != 1 {
+				t.Errorf("Moniker(%s, %q) returned %d monikers, want 1", test.file, test.re, len(monikers))
+				continue
+			}
+			m := monikers[0]
+			got := fmt.Sprintf("%s %s %s %s", m.Scheme, m.Identifier, m.Unique, *m.Kind)
+			if got != test.want {
+				t.Errorf("Moniker(%s, %q) = %q, want %q", test.file, test.re, got, test.want)
+			}
+		}
+	})
+}
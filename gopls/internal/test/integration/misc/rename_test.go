@@ -1024,3 +1024,141 @@ func checkTestdata(t *testing.T, env *Env) {
 		}
 	}
 }
+
+func TestWillRenameFilesMovesPackage(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.18
+-- lib/a.go --
+package lib
+
+import "mod.com/lib/nested"
+
+const A = nested.C
+-- lib/a_test.go --
+package lib_test
+
+import (
+	"testing"
+
+	"mod.com/lib"
+)
+
+func TestA(t *testing.T) { _ = lib.A }
+-- lib/nested/a.go --
+package nested
+
+const C = 1
+-- main.go --
+package main
+
+import (
+	"mod.com/lib"
+	"mod.com/lib/nested"
+)
+
+func main() {
+	println(lib.A, nested.C)
+}
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("main.go")
+		env.WillRenameFiles("lib", "lib2")
+		env.RenameFile("lib", "lib2")
+
+		env.RegexpSearch("lib2/a.go", "package lib2")
+		env.RegexpSearch("lib2/a.go", `"mod.com/lib2/nested"`)
+		env.RegexpSearch("lib2/a_test.go", "package lib2_test")
+		env.RegexpSearch("lib2/a_test.go", `lib2.A`)
+		env.RegexpSearch("lib2/nested/a.go", "package nested")
+		env.RegexpSearch("main.go", `"mod.com/lib2"`)
+		env.RegexpSearch("main.go", `"mod.com/lib2/nested"`)
+		env.RegexpSearch("main.go", `lib2.A`)
+		env.AfterChange(NoDiagnostics())
+	})
+}
+
+func TestWillRenameFilesMovesNestedPackage(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.18
+-- a/lib/lib.go --
+package lib
+
+const A = 1
+-- main.go --
+package main
+
+import "mod.com/a/lib"
+
+func main() {
+	println(lib.A)
+}
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		// The edits are computed before the move, so we check them
+		// without performing the move itself.
+		env.WillRenameFiles("a/lib", "b/c/lib")
+		env.RegexpSearch("a/lib/lib.go", "package lib")
+		env.RegexpSearch("main.go", `"mod.com/b/c/lib"`)
+		env.RegexpSearch("main.go", `lib.A`)
+	})
+}
+
+func TestWillRenameFilesOutsideModule(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.18
+-- lib/a.go --
+package lib
+
+const A = 1
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		err := env.Editor.WillRenameFiles(env.Ctx, "lib", "../lib")
+		if err == nil || !strings.Contains(err.Error(), "outside of module") {
+			t.Errorf("WillRenameFiles outside module: got error %v, want 'outside of module'", err)
+		}
+	})
+}
+
+func TestWillRenameFilesMovesReplacedModule(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.18
+
+require example.com/dep v0.0.0
+
+replace example.com/dep => ./third_party/dep
+-- main.go --
+package main
+
+import "example.com/dep"
+
+func main() {
+	println(dep.A)
+}
+-- third_party/dep/go.mod --
+module example.com/dep
+
+go 1.18
+-- third_party/dep/dep.go --
+package dep
+
+const A = 1
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("main.go")
+		env.WillRenameFiles("third_party", "deps")
+		env.RegexpSearch("go.mod", `=> \./deps/dep`)
+		env.RegexpSearch("main.go", `"example.com/dep"`)
+	})
+}
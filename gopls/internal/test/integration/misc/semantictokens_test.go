@@ -523,3 +523,100 @@ func B(l []string) {}
 		}
 	})
 }
+
+func TestSemanticTokensFullDelta(t *testing.T) {
+	const src = `
+-- go.mod --
+module example.com
+
+go 1.19
+-- main.go --
+package main
+
+func main() {
+	x := 1
+	_ = x
+}
+
+func f() {}
+`
+	WithOptions(
+		Modes(Default),
+		Settings{"semanticTokens": true},
+	).Run(t, src, func(t *testing.T, env *Env) {
+		env.OpenFile("main.go")
+		uri := env.Sandbox.Workdir.URI("main.go")
+		full, err := env.Editor.Server.SemanticTokensFull(env.Ctx, &protocol.SemanticTokensParams{
+			TextDocument: protocol.TextDocumentIdentifier{URI: uri},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if full.ResultID == "" {
+			t.Fatal("SemanticTokensFull returned no result ID")
+		}
+
+		// delta requests a delta against the given result ID,
+		// and decodes the response as a delta if it has edits.
+		delta := func(prevID string) (*protocol.SemanticTokensDelta, *protocol.SemanticTokens) {
+			res, err := env.Editor.Server.SemanticTokensFullDelta(env.Ctx, &protocol.SemanticTokensDeltaParams{
+				TextDocument:     protocol.TextDocumentIdentifier{URI: uri},
+				PreviousResultID: prevID,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := json.Marshal(res)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Contains(string(data), `"edits"`) {
+				var d protocol.SemanticTokensDelta
+				if err := json.Unmarshal(data, &d); err != nil {
+					t.Fatal(err)
+				}
+				return &d, nil
+			}
+			var tokens protocol.SemanticTokens
+			if err := json.Unmarshal(data, &tokens); err != nil {
+				t.Fatal(err)
+			}
+			return nil, &tokens
+		}
+
+		// Insert a statement, and check that applying the delta to the
+		// previous tokens yields the current full tokens.
+		env.RegexpReplace("main.go", "_ = x", "_ = x\n\tprintln(x)")
+		d, _ := delta(full.ResultID)
+		if d == nil {
+			t.Fatalf("SemanticTokensFullDelta(%q) returned full tokens, want delta", full.ResultID)
+		}
+		if len(d.Edits) != 1 {
+			t.Fatalf("got %d edits, want 1", len(d.Edits))
+		}
+		edit := d.Edits[0]
+		got := append(append(append([]uint32(nil), full.Data[:edit.Start]...), edit.Data...), full.Data[edit.Start+edit.DeleteCount:]...)
+		_, current := delta("unknown")
+		if current == nil {
+			t.Fatal("SemanticTokensFullDelta with unknown result ID returned a delta, want full tokens")
+		}
+		if diff := cmp.Diff(current.Data, got); diff != "" {
+			t.Errorf("applying delta to previous tokens: mismatch (-want +got):\n%s", diff)
+		}
+		if len(edit.Data) >= len(current.Data) {
+			t.Errorf("delta inserts %d integers, not fewer than full result (%d)", len(edit.Data), len(current.Data))
+		}
+
+		// An unchanged document has an empty delta.
+		d, _ = delta(current.ResultID)
+		if d == nil || len(d.Edits) != 0 {
+			t.Errorf("SemanticTokensFullDelta of unchanged document = %v, want no edits", d)
+		}
+
+		// A stale result ID, already replaced by a later result,
+		// yields full tokens.
+		if _, tokens := delta(full.ResultID); tokens == nil {
+			t.Errorf("SemanticTokensFullDelta with stale result ID returned a delta, want full tokens")
+		}
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"golang.org/x/tools/gopls/internal/protocol"
 	"golang.org/x/tools/gopls/internal/settings"
 	. "golang.org/x/tools/gopls/internal/test/integration"
 )
@@ -102,6 +103,62 @@ const (
 	})
 }
 
+func TestResolveWorkspaceSymbol(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.17
+-- a/a.go --
+package a
+
+type T struct{ F int }
+
+func (T) Method() {}
+
+type U struct{}
+
+func (U) Method() {}
+`
+	const capabilities = `{
+		"workspace": {
+			"symbol": {
+				"resolveSupport": {"properties": ["location.range"]}
+			}
+		}
+	}`
+
+	WithOptions(
+		CapabilitiesJSON([]byte(capabilities)),
+	).Run(t, files, func(t *testing.T, env *Env) {
+		syms, err := env.Editor.Server.Symbol(env.Ctx, &protocol.WorkspaceSymbolParams{Query: "Method"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := map[string]protocol.Location{
+			"T.Method": env.RegexpSearch("a/a.go", `\(T\) (Method)`),
+			"U.Method": env.RegexpSearch("a/a.go", `\(U\) (Method)`),
+		}
+		if len(syms) != len(want) {
+			t.Fatalf("Symbol(Method) returned %d symbols, want %d", len(syms), len(want))
+		}
+		for _, sym := range syms {
+			// The location specifies only the file, and so is
+			// decoded as a Location with an empty range.
+			if loc, ok := sym.Location.Value.(protocol.Location); !ok || loc.Range != (protocol.Range{}) {
+				t.Errorf("Symbol(Method) returned location %v for %s, want only a URI", sym.Location.Value, sym.Name)
+			}
+			got, err := env.Editor.Server.ResolveWorkspaceSymbol(env.Ctx, &sym)
+			if err != nil {
+				t.Fatalf("ResolveWorkspaceSymbol(%q) failed: %v", sym.Name, err)
+			}
+			if diff := cmp.Diff(want[sym.Name], got.Location.Value); diff != "" {
+				t.Errorf("ResolveWorkspaceSymbol(%q) location mismatch (-want +got):\n%s", sym.Name, diff)
+			}
+		}
+	})
+}
+
 func checkSymbols(env *Env, query string, want ...string) {
 	env.TB.Helper()
 	var got []string
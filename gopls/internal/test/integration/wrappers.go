@@ -303,6 +303,17 @@ func (e *Env) DocumentHighlight(loc protocol.Location) []protocol.DocumentHighli
 	return highlights
 }
 
+// LinkedEditingRange invokes textDocument/linkedEditingRange at the given
+// location, calling t.Fatal on any error.
+func (e *Env) LinkedEditingRange(loc protocol.Location) *protocol.LinkedEditingRanges {
+	e.TB.Helper()
+	ranges, err := e.Editor.LinkedEditingRange(e.Ctx, loc)
+	if err != nil {
+		e.TB.Fatal(err)
+	}
+	return ranges
+}
+
 // RunGenerate runs "go generate" in the given dir, calling t.Fatal on any error.
 // It waits for the generate command to complete and checks for file changes
 // before returning.
@@ -517,6 +528,22 @@ func (e *Env) RenameFile(oldPath, newPath string) {
 	}
 }
 
+// WillRenameFiles wraps Editor.WillRenameFiles, calling t.Fatal on any error.
+func (e *Env) WillRenameFiles(oldPath, newPath string) {
+	e.TB.Helper()
+	if err := e.Editor.WillRenameFiles(e.Ctx, oldPath, newPath); err != nil {
+		e.TB.Fatal(err)
+	}
+}
+
+// DeleteFiles wraps Editor.DeleteFiles, calling t.Fatal on any error.
+func (e *Env) DeleteFiles(paths ...string) {
+	e.TB.Helper()
+	if err := e.Editor.DeleteFiles(e.Ctx, paths...); err != nil {
+		e.TB.Fatal(err)
+	}
+}
+
 // SignatureHelp wraps Editor.SignatureHelp, calling t.Fatal on error
 func (e *Env) SignatureHelp(loc protocol.Location) *protocol.SignatureHelp {
 	e.TB.Helper()
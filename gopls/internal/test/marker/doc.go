@@ -237,6 +237,11 @@ Here is the list of supported action markers:
     (These locations are the declarations of the functions enclosing
     the calls, not the calls themselves.)
 
+  - linkedediting(src location, want ...location): makes a
+    textDocument/linkedEditingRange request at the src location, and
+    checks that the resulting ranges match want. If want is empty, the
+    result must be empty.
+
   - outgoingcalls(src location, want ...location): makes a
     callHierarchy/outgoingCalls query at the src location, and checks that
     the set of call.To locations matches want.
@@ -258,6 +263,12 @@ Here is the list of supported action markers:
     (Failures in the computation to offer a fix do not generally result
     in LSP errors, so this marker is not appropriate for testing them.)
 
+  - rangeformat(start location, golden, end=location): performs a
+    textDocument/rangeFormatting request for the range from start to end
+    (or the start location alone, if end is omitted), and compares the
+    resulting file content against the named golden file. If the request
+    fails, the golden file must contain the error message.
+
   - rank(location, ...string OR completionItem): executes a
     textDocument/completion request at the given location, and verifies that
     each expected completion item occurs in the results, in the expected order.
@@ -326,7 +337,7 @@ Here is the list of supported action markers:
 
     location name kind
 
-  - mcptool(name string, arg string, location=location, output=golden):
+  - mcptool(name string, arg string, location=location, output=golden, structured=golden):
     Executes an MCP tool call using the provided tool name and args (a
     JSON-encoded value). Any string or []string values in the JSON input object
     are modified to replace the substring '$WORKDIR' with the actual working
@@ -335,7 +346,18 @@ Here is the list of supported action markers:
     source location. The test then asserts that the MCP server's response
     matches the content of the golden file identified by output. For
     portability, all filepath separators in the output are normalized to '/',
-    even if they occur outside of a path context.
+    even if they occur outside of a path context. If 'structured' is
+    provided, the test also asserts that the structured content of the
+    response, as indented JSON, matches the golden file it identifies.
+
+  - mcpresource(uri string, output=golden): reads the MCP resource with the
+    given URI, and asserts that its contents match the golden file identified
+    by output, with paths normalized as for mcptool.
+
+  - mcpprompt(name string, args string, output=golden): gets the MCP prompt
+    with the given name and arguments (a JSON object of strings), and asserts
+    that its messages match the golden file identified by output. Embedded
+    resources are summarized by their URI and length.
 
 # Expected locations
 Marker tests that compare expected sets of locations (e.g. def, refs) only check
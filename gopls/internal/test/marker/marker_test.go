@@ -620,6 +620,7 @@ var actionMarkerFuncs = map[string]func(marker){
 	"implementation":   actionMarkerFunc(implementationMarker, "err"),
 	"incomingcalls":    actionMarkerFunc(incomingCallsMarker),
 	"inlayhints":       actionMarkerFunc(inlayhintsMarker),
+	"linkedediting":    actionMarkerFunc(linkedEditingMarker),
 	"outgoingcalls":    actionMarkerFunc(outgoingCallsMarker),
 	"preparerename":    actionMarkerFunc(prepareRenameMarker, "span"),
 	"rank":             actionMarkerFunc(rankMarker),
@@ -633,11 +634,14 @@ var actionMarkerFuncs = map[string]func(marker){
 	"supertypes":       actionMarkerFunc(supertypesMarker),
 	"quickfix":         actionMarkerFunc(quickfixMarker),
 	"quickfixerr":      actionMarkerFunc(quickfixErrMarker),
+	"rangeformat":      actionMarkerFunc(rangeFormatMarker, "end"),
 	"symbol":           actionMarkerFunc(symbolMarker),
 	"token":            actionMarkerFunc(tokenMarker),
 	"typedef":          actionMarkerFunc(typedefMarker, "err"),
 	"workspacesymbol":  actionMarkerFunc(workspaceSymbolMarker),
-	"mcptool":          actionMarkerFunc(mcpToolMarker, "location", "output"),
+	"mcptool":          actionMarkerFunc(mcpToolMarker, "location", "output", "structured"),
+	"mcpresource":      actionMarkerFunc(mcpResourceMarker, "output"),
+	"mcpprompt":        actionMarkerFunc(mcpPromptMarker, "output"),
 }
 
 // markerTest holds all the test data extracted from a test txtar archive.
@@ -1034,7 +1038,7 @@ func newEnv(t *testing.T, cache *cache.Cache, files, proxyFiles map[string][]byt
 
 	var mcpServer *httptest.Server
 	if enableMCP {
-		mcpServer = httptest.NewServer(internalmcp.HTTPHandler(ss, false, nil))
+		mcpServer = httptest.NewServer(internalmcp.HTTPHandler(ss, internalmcp.Streamable, false, nil))
 	}
 
 	server := servertest.NewPipeServer(ss, jsonrpc2.NewRawStream)
@@ -1055,7 +1059,7 @@ func newEnv(t *testing.T, cache *cache.Cache, files, proxyFiles map[string][]byt
 	var mcpSession *mcp.ClientSession
 	if enableMCP {
 		client := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "v1.0.0"}, nil)
-		mcpSession, err = client.Connect(ctx, &mcp.SSEClientTransport{Endpoint: mcpServer.URL}, nil)
+		mcpSession, err = client.Connect(ctx, &mcp.StreamableClientTransport{Endpoint: mcpServer.URL}, nil)
 		if err != nil {
 			t.Fatalf("fail to connect to mcp server: %v", err)
 		}
@@ -1818,25 +1822,42 @@ func formatMarker(mark marker, golden *Golden) {
 	edits, err := mark.server().Formatting(mark.ctx(), &protocol.DocumentFormattingParams{
 		TextDocument: mark.document(),
 	})
-	var got []byte
-	if err != nil {
-		got = []byte(err.Error() + "\n") // all golden content is newline terminated
-	} else {
-		env := mark.run.env
-		filename := mark.path()
-		mapper, err := env.Editor.Mapper(filename)
-		if err != nil {
-			mark.errorf("Editor.Mapper(%s) failed: %v", filename, err)
-		}
+	compareGolden(mark, formattedContent(mark, edits, err), golden)
+}
 
-		got, _, err = protocol.ApplyEdits(mapper, edits)
-		if err != nil {
-			mark.errorf("ApplyProtocolEdits failed: %v", err)
-			return
-		}
+// rangeFormatMarker implements the @rangeformat marker.
+func rangeFormatMarker(mark marker, start protocol.Location, golden *Golden) {
+	loc := start
+	if end := namedArgFunc(mark, "end", convertNamedArgLocation, protocol.Location{}); end.URI != "" {
+		loc.Range.End = end.Range.End
+	}
+	edits, err := mark.server().RangeFormatting(mark.ctx(), &protocol.DocumentRangeFormattingParams{
+		TextDocument: mark.document(),
+		Range:        loc.Range,
+	})
+	compareGolden(mark, formattedContent(mark, edits, err), golden)
+}
+
+// formattedContent returns the content of the marker's file after
+// applying the result of a formatting request, or the error message
+// if the request failed.
+func formattedContent(mark marker, edits []protocol.TextEdit, err error) []byte {
+	if err != nil {
+		return []byte(err.Error() + "\n") // all golden content is newline terminated
+	}
+	env := mark.run.env
+	filename := mark.path()
+	mapper, err := env.Editor.Mapper(filename)
+	if err != nil {
+		mark.errorf("Editor.Mapper(%s) failed: %v", filename, err)
 	}
 
-	compareGolden(mark, got, golden)
+	got, _, err := protocol.ApplyEdits(mapper, edits)
+	if err != nil {
+		mark.errorf("ApplyProtocolEdits failed: %v", err)
+		return nil
+	}
+	return got
 }
 
 func highlightLocationMarker(mark marker, loc protocol.Location, kindName expect.Identifier) protocol.DocumentHighlight {
@@ -1898,6 +1919,19 @@ func highlightMarker(mark marker, src protocol.DocumentHighlight, dsts ...protoc
 	}
 }
 
+// linkedEditingMarker implements the @linkedediting marker.
+func linkedEditingMarker(mark marker, src protocol.Location, want ...protocol.Location) {
+	var got []protocol.Location
+	if ranges := mark.run.env.LinkedEditingRange(src); ranges != nil {
+		for _, rng := range ranges.Ranges {
+			got = append(got, src.URI.Location(rng))
+		}
+	}
+	if err := compareLocations(mark, got, want); err != nil {
+		mark.errorf("LinkedEditingRange: %v", err)
+	}
+}
+
 func hoverMarker(mark marker, src, dst protocol.Location, sc stringMatcher) {
 	content, gotDst := mark.run.env.Hover(src)
 	if gotDst != dst {
@@ -2439,7 +2473,26 @@ func mcpToolMarker(mark marker, tool string, rawArgs string) {
 		buf.WriteString("\n") // all golden content is newline terminated
 	}
 
-	got := buf.String()
+	checkMCPOutput(mark, "tools call "+tool, "output", buf.String())
+
+	if namedArg(mark, "structured", expect.Identifier("")) != "" {
+		if res.StructuredContent == nil {
+			mark.errorf("tool %s returned no structured content", tool)
+			return
+		}
+		data, err := json.MarshalIndent(res.StructuredContent, "", "\t")
+		if err != nil {
+			mark.errorf("marshaling structured content: %v", err)
+			return
+		}
+		checkMCPOutput(mark, "tools call "+tool+" structured content", "structured", string(data)+"\n")
+	}
+}
+
+// checkMCPOutput compares the output of an MCP request, described by
+// what, with the golden content identified by the named argument of
+// the mark.
+func checkMCPOutput(mark marker, what, arg, got string) {
 	// For portability, replace all (potential) filepath separators with "/".
 	got = strings.ReplaceAll(got, string(filepath.Separator), "/")
 	// To ensure consistent unified diff output, the working directory path
@@ -2447,12 +2500,61 @@ func mcpToolMarker(mark marker, tool string, rawArgs string) {
 	// include absolute file paths in generated diffs.
 	got = strings.ReplaceAll(got, filepath.ToSlash(mark.run.env.Sandbox.Workdir.RootURI().Path()), "$WORKDIR")
 
-	output := namedArg(mark, "output", expect.Identifier(""))
+	output := namedArg(mark, arg, expect.Identifier(""))
 	golden := mark.getGolden(output)
 	want, _ := golden.Get(mark.T(), "", []byte(got))
 	if diff := compare.Text(string(want), got); diff != "" {
-		mark.errorf("unexpected mcp tools call %s return: diff:\n%s", tool, diff)
+		mark.errorf("unexpected mcp %s return: diff:\n%s", what, diff)
+	}
+}
+
+func mcpResourceMarker(mark marker, uri string) {
+	if !mark.run.test.mcp {
+		mark.errorf("mcp not enabled: add -mcp")
+		return
+	}
+	res, err := mark.run.env.MCPSession.ReadResource(mark.ctx(), &mcp.ReadResourceParams{URI: uri})
+	if err != nil {
+		mark.errorf("failed to read mcp resource: %v", err)
+		return
+	}
+	var buf bytes.Buffer
+	for _, c := range res.Contents {
+		fmt.Fprintf(&buf, "%s (%s):\n%s", c.URI, c.MIMEType, c.Text)
+	}
+	checkMCPOutput(mark, "resource "+uri, "output", buf.String())
+}
+
+func mcpPromptMarker(mark marker, name string, rawArgs string) {
+	if !mark.run.test.mcp {
+		mark.errorf("mcp not enabled: add -mcp")
+		return
+	}
+	args := make(map[string]string)
+	if err := json.Unmarshal([]byte(rawArgs), &args); err != nil {
+		mark.errorf("fail to unmarshal arguments to map[string]string: %v", err)
+		return
+	}
+	res, err := mark.run.env.MCPSession.GetPrompt(mark.ctx(), &mcp.GetPromptParams{
+		Name:      name,
+		Arguments: args,
+	})
+	if err != nil {
+		mark.errorf("failed to get mcp prompt: %v", err)
+		return
+	}
+	var buf bytes.Buffer
+	for _, msg := range res.Messages {
+		switch c := msg.Content.(type) {
+		case *mcp.TextContent:
+			fmt.Fprintf(&buf, "%s: text:\n%s\n", msg.Role, c.Text)
+		case *mcp.EmbeddedResource:
+			fmt.Fprintf(&buf, "%s: resource %s (%d bytes)\n", msg.Role, c.Resource.URI, len(c.Resource.Text))
+		default:
+			mark.errorf("unsupported prompt content type: %T", c)
+		}
 	}
+	checkMCPOutput(mark, "prompt "+name, "output", buf.String())
 }
 
 func incomingCallsMarker(mark marker, src protocol.Location, want ...protocol.Location) {
@@ -2716,7 +2818,8 @@ func workspaceSymbolMarker(mark marker, query string, golden *Golden) {
 	for _, s := range gotSymbols {
 		// Omit the txtar position of the symbol location; otherwise edits to the
 		// txtar archive lead to unexpected failures.
-		loc := mark.run.fmtLocForGolden(s.Location)
+		symLoc, _ := s.Location.Value.(protocol.Location)
+		loc := mark.run.fmtLocForGolden(symLoc)
 		if loc == "" {
 			loc = "<unknown>"
 		}
@@ -6,6 +6,7 @@ package diff_test
 
 import (
 	"bytes"
+	"fmt"
 	"math/rand"
 	"os"
 	"os/exec"
@@ -168,6 +169,79 @@ func TestToUnified(t *testing.T) {
 	}
 }
 
+func TestApplyUnified(t *testing.T) {
+	for _, tc := range difftest.TestCases {
+		for _, context := range []int{0, diff.DefaultContextLines} {
+			t.Run(fmt.Sprintf("%s/context=%d", tc.Name, context), func(t *testing.T) {
+				unified, err := diff.ToUnified(difftest.FileA, difftest.FileB, tc.In, diff.Lines(tc.In, tc.Out), context)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, err := diff.ApplyUnified(unified, tc.In)
+				if err != nil {
+					t.Fatalf("ApplyUnified failed: %v\nunified:\n%s", err, unified)
+				}
+				if got != tc.Out {
+					t.Errorf("ApplyUnified: got %q, want %q\nunified:\n%s", got, tc.Out, unified)
+				}
+			})
+		}
+	}
+
+	// Hand-written diffs must match the text exactly.
+	const in = "a\nb\n\nc\nd\ne\n"
+	for _, tc := range []struct {
+		name, unified, want, err string
+	}{
+		{
+			name:    "exact",
+			unified: "--- a\n+++ b\n@@ -3,3 +3,3 @@\n \n-c\n+C\n d\n",
+			want:    "a\nb\n\nC\nd\ne\n",
+		},
+		{
+			name:    "insert after",
+			unified: "@@ -2,0 +3 @@\n+B\n",
+			want:    "a\nb\nB\n\nc\nd\ne\n",
+		},
+		{
+			name:    "offset",
+			unified: "@@ -1,2 +1,2 @@\n-d\n+D\n e\n",
+			err:     "does not match the text at line 1",
+		},
+		{
+			name:    "empty context",
+			unified: "@@ -2,3 +2,3 @@\n b\n\n-c\n+C\n",
+			err:     "invalid empty line",
+		},
+		{
+			name:    "no newline",
+			unified: "@@ -5,2 +5,2 @@\n d\n-e\n+E\n\\ No newline at end of file\n",
+			want:    "a\nb\n\nc\nd\nE",
+		},
+		{
+			name:    "mismatch",
+			unified: "@@ -1,2 +1,2 @@\n a\n-c\n+C\n",
+			err:     "does not match",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := diff.ApplyUnified(tc.unified, in)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("ApplyUnified: got (%q, %v), want error containing %q", got, err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("ApplyUnified: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestRegressionOld001(t *testing.T) {
 	a := "// Copyright 2019 The Go Authors. All rights reserved.\n// Use of this source code is governed by a BSD-style\n// license that can be found in the LICENSE file.\n\npackage diff_test\n\nimport (\n\t\"fmt\"\n\t\"math/rand\"\n\t\"strings\"\n\t\"testing\"\n\n\t\"golang.org/x/tools/gopls/internal/lsp/diff\"\n\t\"golang.org/x/tools/internal/diff/difftest\"\n\t\"golang.org/x/tools/gopls/internal/span\"\n)\n"
 
@@ -265,50 +265,133 @@ func (u unified) String() string {
 	return b.String()
 }
 
-// ApplyUnified applies the unified diffs.
+// ApplyUnified applies a unified diff of a single file, such as one
+// produced by Unified, to the before text, and returns the result.
+// Lines that precede the first hunk, such as the "---" and "+++" file
+// headers, are ignored.
+//
+// The context and deleted lines of each hunk must match the text at
+// the line given by the hunk header; otherwise ApplyUnified reports an
+// error.
 func ApplyUnified(udiffs, bef string) (string, error) {
+	hunks, err := parseUnified(udiffs)
+	if err != nil {
+		return "", err
+	}
 	before := strings.Split(bef, "\n")
-	unif := strings.Split(udiffs, "\n")
 	var got []string
 	left := 0
-	// parse and apply the unified diffs
-	for _, l := range unif {
-		if len(l) == 0 {
-			continue // probably the last line (from Split)
+	for _, h := range hunks {
+		if h.fromLine < left || h.fromLine > len(before) {
+			return "", fmt.Errorf("hunk %q is out of order or out of range", h.header)
 		}
-		switch l[0] {
-		case '@': // The @@ line
+		got = append(got, before[left:h.fromLine]...)
+		left = h.fromLine
+		for _, l := range h.lines {
+			switch l.kind {
+			case opEqual, opDelete:
+				if left >= len(before) || before[left] != l.content {
+					return "", fmt.Errorf("hunk %q does not match the text at line %d", h.header, left+1)
+				}
+				if l.kind == opEqual {
+					got = append(got, before[left])
+				}
+				left++
+			case opInsert:
+				got = append(got, l.content)
+			}
+		}
+	}
+	// copy any remaining lines
+	got = append(got, before[left:]...)
+
+	// Only the last hunk may reach the end of a text with no final newline.
+	if len(hunks) > 0 {
+		last := hunks[len(hunks)-1]
+		switch {
+		case last.noNewline && !last.oldNoNewline:
+			if len(got) > 1 && got[len(got)-1] == "" {
+				got = got[:len(got)-1]
+			}
+		case last.oldNoNewline && !last.noNewline:
+			got = append(got, "")
+		}
+	}
+	return strings.Join(got, "\n"), nil
+}
+
+// A patchHunk is a hunk of a parsed unified diff.
+// The content of its lines has no final newline.
+type patchHunk struct {
+	header       string // the @@ line
+	fromLine     int    // 0-based index of the first line of the hunk in the before text
+	lines        []line
+	noNewline    bool // the after text has no final newline
+	oldNoNewline bool // the before text has no final newline
+}
+
+// parseUnified parses the hunks of a unified diff.
+func parseUnified(udiffs string) ([]*patchHunk, error) {
+	unif := strings.Split(udiffs, "\n")
+	for len(unif) > 0 && unif[len(unif)-1] == "" {
+		unif = unif[:len(unif)-1] // trailing empty lines are not context
+	}
+	var (
+		hunks []*patchHunk
+		h     *patchHunk
+	)
+	for _, l := range unif {
+		if strings.HasPrefix(l, "@@") {
 			m := atregexp.FindStringSubmatch(l)
+			if m == nil {
+				return nil, fmt.Errorf("invalid hunk header %q", l)
+			}
 			fromLine, err := strconv.Atoi(m[1])
 			if err != nil {
-				return "", fmt.Errorf("missing line number in %q", l)
-			}
-			// before is a slice, so0-based; fromLine is 1-based
-			for ; left < fromLine-1; left++ {
-				got = append(got, before[left])
-			}
-		case '+': // add this line
-			if strings.HasPrefix(l, "+++ ") {
-				continue
+				return nil, fmt.Errorf("missing line number in %q", l)
 			}
-			got = append(got, l[1:])
-		case '-': // delete this line
-			if strings.HasPrefix(l, "--- ") {
-				continue
+			// A hunk that deletes no lines is inserted after the
+			// line of its header if it has an explicit zero count
+			// (as in "-5,0"), and before it otherwise (as in "-5"),
+			// as output by Unified.
+			if m[2] != "0" && fromLine > 0 {
+				fromLine--
 			}
-			left++
+			h = &patchHunk{header: l, fromLine: fromLine}
+			hunks = append(hunks, h)
+			continue
+		}
+		if h == nil {
+			continue // file headers
+		}
+		if l == "" {
+			return nil, fmt.Errorf("invalid empty line in unified diff")
+		}
+		switch l[0] {
 		case ' ':
-			return "", fmt.Errorf("unexpected line %q", l)
+			h.lines = append(h.lines, line{kind: opEqual, content: l[1:]})
+		case '+':
+			h.lines = append(h.lines, line{kind: opInsert, content: l[1:]})
+		case '-':
+			h.lines = append(h.lines, line{kind: opDelete, content: l[1:]})
+		case '\\': // "\ No newline at end of file"
+			if n := len(h.lines); n > 0 {
+				switch h.lines[n-1].kind {
+				case opInsert:
+					h.noNewline = true
+				case opDelete:
+					h.oldNoNewline = true
+				case opEqual:
+					h.noNewline, h.oldNoNewline = true, true
+				}
+			}
 		default:
-			return "", fmt.Errorf("invalid unified diff: <<%s>>", udiffs)
+			return nil, fmt.Errorf("invalid line %q in unified diff", l)
 		}
 	}
-	// copy any remaining lines
-	for ; left < len(before); left++ {
-		got = append(got, before[left])
-	}
-	return strings.Join(got, "\n"), nil
+	return hunks, nil
 }
 
-// The first number in the @@ lines is the line number in the 'before' data
-var atregexp = regexp.MustCompile(`@@ -(\d+).* @@`)
+// The first number in the @@ lines is the line number in the 'before' data,
+// and the second, if any, is the number of lines.
+var atregexp = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? .*@@`)